@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"rangequery"
+)
 
 // ---------- ARRAY EXAMPLE ----------
 func arrayExample() {
@@ -109,7 +112,8 @@ func rangeGotcha() {
 	s := []int{1, 2, 3}
 
 	for _, v := range s {
-		v = 10 // does NOT modify slice
+		v = 10 // does NOT modify slice: v is a copy
+		_ = v  // (and the compiler rejects a copy that is set but never read)
 	}
 	fmt.Println("After wrong range:", s)
 
@@ -133,6 +137,25 @@ func multiDimSlice() {
 	fmt.Println("Grid:", grid)
 }
 
+// ---------- RANGE QUERIES ----------
+func rangeQueries() {
+	fmt.Println("\n=== RANGE QUERIES ===")
+
+	sales := []int{5, 3, 8, 6, 1, 4}
+
+	// Prefix sums with point updates, both O(log n)
+	f := rangequery.NewFenwick(sales)
+	fmt.Println("Sum of days 1..3:", f.Sum(1, 4))
+	f.Add(2, 10)
+	fmt.Println("After adding 10 to day 2:", f.Sum(1, 4))
+
+	// Range minimum with a range update
+	m := rangequery.NewMinTree(sales)
+	fmt.Println("Min of days 2..5:", m.Query(2, 6))
+	m.Update(0, 3, -5) // subtract 5 from days 0..2
+	fmt.Println("After discount:", m.Values(), "min:", m.Query(0, 6))
+}
+
 // ---------- MAIN ----------
 func main() {
 	arrayExample()
@@ -143,5 +166,6 @@ func main() {
 	sliceInFunction()
 	rangeGotcha()
 	multiDimSlice()
+	rangeQueries()
 }
 
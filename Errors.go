@@ -1,17 +1,27 @@
 package main
 
 import (
+	"errcode"
 	"errors"
 	"fmt"
+	"opt"
 )
 
 func divide(a, b int) (int, error) {
 	if b == 0 {
-		return 0, errors.New("cannot divide by zero")
+		return 0, errcode.MathDivZero.With("dividend", a)
 	}
 	return a / b, nil
 }
 
+func setAge(ages map[string]int, name string, age int) error {
+	if age < 0 {
+		return errcode.PersonAgeNegative.With("name", name).With("age", age)
+	}
+	ages[name] = age
+	return nil
+}
+
 func main() {
 	result, err := divide(10, 0)
 	if err != nil {
@@ -20,9 +30,40 @@ func main() {
 		fmt.Println("Result:", result)
 	}
 
+	// Match on the stable code, not on the message text
+	if errors.Is(err, errcode.MathDivZero) {
+		fmt.Println("Code:", errcode.MathDivZero.Code)
+	}
+
+	// Same error, translated
+	var ce *errcode.Error
+	if errors.As(err, &ce) {
+		fmt.Println("In Spanish:", ce.Localize("es"))
+	}
+
 	result, err = divide(10, 2)
 	if err == nil {
 		fmt.Println("Result:", result)
 	}
-}
 
+	// The same calls without branching on err each time
+	half := opt.From(divide(10, 2))
+	quarter := opt.AndThenResult(half, func(x int) (int, error) { return divide(x, 2) })
+	fmt.Println("Chained:", quarter)                               // Ok(2)
+	fmt.Println("Fallback:", opt.From(divide(10, 0)).UnwrapOr(-1)) // -1
+
+	// Comma-ok map lookup as an Option
+	ages := map[string]int{"Alice": 25}
+	fmt.Println("Eve:", opt.Lookup(ages, "Eve").UnwrapOr(0))
+
+	// Another code from the same catalog, in French this time
+	if err := setAge(ages, "Bob", -3); errors.Is(err, errcode.PersonAgeNegative) {
+		fmt.Println("Error:", err)
+		if errors.As(err, &ce) {
+			fmt.Println("In French:", ce.Localize("fr"))
+		}
+	}
+
+	// A sentinel never passed through With marks its missing parameters
+	fmt.Println("Bare:", errcode.MathDivZero) // cannot divide <dividend> by zero
+}
@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+	"iterx"
+	"tuple"
+)
 
 /*
 GENERICS IN GO:
@@ -15,9 +20,10 @@ GENERICS IN GO:
 // 1. Generic function
 // ----------------------
 
-// PrintSlice prints all elements of a slice of any type
-func PrintSlice[T any](s []T) {
-	for i, v := range s {
+// PrintSlice prints all elements of a sequence of any type.
+// A sequence can come from a slice, a map, a channel, or be computed lazily.
+func PrintSlice[T any](s iter.Seq[T]) {
+	for i, v := range iterx.Enumerate(s) {
 		fmt.Printf("Index %d: %v\n", i, v)
 	}
 }
@@ -35,16 +41,8 @@ func Swap[T any](a, b T) (T, T) {
 // 3. Generic struct
 // ----------------------
 
-// Pair holds two values of the same type
-type Pair[T any] struct {
-	First  T
-	Second T
-}
-
-// Print prints the values of the Pair
-func (p Pair[T]) Print() {
-	fmt.Println("First:", p.First, "Second:", p.Second)
-}
+// tuple.Pair[A, B] has one type parameter per field,
+// so First and Second may have different types.
 
 // ----------------------
 // 4. Main function demonstrating all
@@ -55,8 +53,12 @@ func main() {
 	stringSlice := []string{"apple", "banana", "cherry"}
 
 	// Can use the same PrintSlice function for any type
-	PrintSlice(intSlice)
-	PrintSlice(stringSlice)
+	PrintSlice(iterx.FromSlice(intSlice))
+	PrintSlice(iterx.FromSlice(stringSlice))
+
+	// ...and for sequences that are never stored in a slice
+	evens := iterx.Filter(iterx.Range(0, 1_000_000, 1), func(n int) bool { return n%2 == 0 })
+	PrintSlice(iterx.Take(evens, 3)) // only the first few values are ever computed
 
 	fmt.Println("\n=== 2. Generic Swap Example ===")
 	a, b := 10, 20
@@ -68,11 +70,15 @@ func main() {
 	fmt.Println("Swapped strings:", x, y)
 
 	fmt.Println("\n=== 3. Generic Struct Example ===")
-	intPair := Pair[int]{First: 5, Second: 10}
-	stringPair := Pair[string]{First: "foo", Second: "bar"}
+	intPair := tuple.Pair[int, int]{First: 5, Second: 10}
+	person := tuple.NewPair("Alice", 30) // Pair[string, int], types inferred
+
+	fmt.Println("Int pair:", intPair)      // String() prints (5, 10)
+	fmt.Println("Person:", person)         // (Alice, 30)
+	fmt.Println("Swapped:", person.Swap()) // (30, Alice)
 
-	intPair.Print()
-	stringPair.Print()
+	name, age := person.Unpack()
+	fmt.Println(name, "is", age)
 }
 
 /*
@@ -81,16 +87,17 @@ Explanation:
 1. PrintSlice[T any]:
    - T is a type parameter.
    - any means T can be any type (int, string, float, etc.)
-   - This lets us use the same function for slices of different types.
+   - It takes an iter.Seq[T], so slices, maps, channels and lazy
+     pipelines built with iterx can all be printed the same way.
 
 2. Swap[T any]:
    - Generic function to swap values of any type.
    - Avoids writing multiple swap functions for int, string, etc.
 
-3. Pair[T any]:
-   - Generic struct holding two values of the same type.
-   - Method Print prints the values.
-   - Works for int, string, or any type we choose.
+3. tuple.Pair[A, B any]:
+   - Generic struct with two type parameters, one per field.
+   - Pair[int, int] and Pair[string, int] are both valid.
+   - Swap returns Pair[B, A]; String prints (first, second).
 
 Key idea:
 - Generics reduce code duplication.
@@ -1,6 +1,12 @@
 package main
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"geometry"
+	"os"
+	"path/filepath"
+)
 
 // =====================
 // 1. Define an interface
@@ -12,6 +18,9 @@ type Shape interface {
 	Area() float64
 }
 
+// The geometry package defines a bigger geometry.Shape, with Area and
+// Perimeter, and the shapes below that implement it.
+
 // =====================
 // 2. Structs that implement the interface
 // =====================
@@ -26,15 +35,9 @@ func (r Rectangle) Area() float64 {
 	return r.Width * r.Height
 }
 
-// Circle has a Radius field
-type Circle struct {
-	Radius float64
-}
-
-// Circle implements the Area method, satisfying the Shape interface
-func (c Circle) Area() float64 {
-	return 3.14 * c.Radius * c.Radius
-}
+// geometry.Rectangle, Circle, Square, Triangle, Ellipse, RegularPolygon and
+// Polygon all implement geometry.Shape. They have an Area method too, so
+// they satisfy our Shape as well, without ever naming it.
 
 // =====================
 // 3. Function that accepts any Shape
@@ -43,31 +46,52 @@ func (c Circle) Area() float64 {
 // printArea accepts a Shape interface
 // This function can take any type that implements Shape
 func printArea(s Shape) {
-	fmt.Println("Area:", s.Area())
+	fmt.Printf("Area: %.2f\n", s.Area())
+}
+
+// printShape needs the bigger geometry.Shape, so it can also ask for the perimeter
+func printShape(s geometry.Shape) {
+	fmt.Printf("Area: %.2f, Perimeter: %.2f\n", s.Area(), s.Perimeter())
 }
 
 // =====================
 // 4. Main function
 // =====================
 func main() {
-	// Create a Rectangle instance
-	r := Rectangle{Width: 5, Height: 4}
-
-	// Create a Circle instance
-	c := Circle{Radius: 3}
+	// Create a Rectangle instance of our own type
+	lr := Rectangle{Width: 5, Height: 4}
+	fmt.Println("Local rectangle:")
+	printArea(lr)
+
+	// and one from the geometry package
+	r := geometry.Rectangle{Width: 5, Height: 4}
+
+	// Create a Circle instance; constructors validate their input
+	c, err := geometry.NewCircle(3)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	if _, err := geometry.NewCircle(-1); err != nil {
+		fmt.Println("Rejected:", err) // geometry: invalid shape: radius must be positive ...
+	}
 
-	// Both r and c implement Shape, so we can pass them to printArea
+	// Both r and c implement geometry.Shape, so we can pass them to printShape
 	fmt.Println("Rectangle:")
-	printArea(r) // Rectangle's Area method will be called
+	printShape(r) // geometry.Rectangle's Area and Perimeter methods will be called
 
 	fmt.Println("Circle:")
-	printArea(c) // Circle's Area method will be called
+	printShape(c) // geometry.Circle's Area and Perimeter methods will be called
+	printArea(c)  // c has an Area method, so it is also one of our Shapes
+
+	// Our Rectangle has no Perimeter method, so it is not a geometry.Shape:
+	// printShape(lr) would not compile.
 
 	// =====================
 	// 5. Interface values explanation
 	// =====================
 
-	var s Shape
+	var s geometry.Shape
 	// At this moment, s has:
 	// - type: nil
 	// - value: nil
@@ -84,7 +108,20 @@ func main() {
 	// 6. Interfaces and slices
 	// =====================
 
-	shapes := []Shape{r, c} // slice of interfaces
+	sq := geometry.Square{Side: 2}
+	tri, _ := geometry.NewTriangle(3, 4, 5)
+	hex, _ := geometry.NewRegularPolygon(6, 1)
+
+	// Every shape embeds a Placement, so it can be positioned and styled
+	// for drawing (section 8). Here they are laid out left to right.
+	r.Pos = geometry.Point{X: 2.5, Y: 2}
+	c.Pos = geometry.Point{X: 9, Y: 3}
+	sq.Pos = geometry.Point{X: 14, Y: 1}
+	tri.Pos = geometry.Point{X: 16, Y: 0}
+	hex.Pos = geometry.Point{X: 23, Y: 1}
+	c.Style = geometry.Style{Fill: "#a6cee3", Stroke: "#1f78b4"}
+
+	shapes := []geometry.Shape{r, c, sq, tri, hex} // slice of interfaces
 	for i, shape := range shapes {
 		fmt.Printf("Shape %d area: %v\n", i+1, shape.Area())
 	}
@@ -93,7 +130,57 @@ func main() {
 	// 7. Nil interface vs non-nil
 	// =====================
 
-	var s2 Shape // nil interface
+	var s2 geometry.Shape             // nil interface
 	fmt.Println("Nil interface:", s2) // prints <nil>
+
+	// =====================
+	// 8. Drawing shapes
+	// =====================
+
+	// WriteSVG fits the viewBox around the shapes and can label their areas.
+	// The file goes to the temp directory so running the lesson leaves no mess.
+	path := filepath.Join(os.TempDir(), "shapes.svg")
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer f.Close()
+	opts := geometry.SVGOptions{Margin: 1, Scale: 20, LabelArea: true}
+	opts.Style.StrokeWidth = 0.1 // thin outlines for shapes without their own
+	if err := geometry.WriteSVG(f, shapes, opts); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Wrote", path)
+
+	// =====================
+	// 9. Interfaces and JSON
+	// =====================
+
+	// Encoding []geometry.Shape directly would lose the concrete types.
+	// geometry.Shapes adds a "type" field to each element instead.
+	data, err := json.Marshal(geometry.Shapes(shapes))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	var decoded geometry.Shapes
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Round trip: %T, %T, ...\n", decoded[0], decoded[1]) // geometry.Rectangle, geometry.Circle
+
+	// =====================
+	// 10. Collisions
+	// =====================
+
+	// Every geometry shape is also a geometry.Collider, with a bounding box
+	// and a point test; Intersects picks the exact test for each pair.
+	fmt.Println("Circle bounds:", c.Bounds())
+	fmt.Println("Circle contains (9, 5):", c.Contains(geometry.Point{X: 9, Y: 5}))
+	fmt.Println("Rectangle hits circle:", geometry.Intersects(r, c))
+	fmt.Println("Square hits triangle:", geometry.Intersects(sq, tri))
 }
 
@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"orderedmap"
+)
 
 func main() {
 	// Classic for loop
@@ -21,10 +24,18 @@ func main() {
 		fmt.Println(i, name)
 	}
 
-	// for range over map
+	// for range over map (order is random on every run)
 	ages := map[string]int{"Alice": 25, "Bob": 30}
 	for name, age := range ages {
 		fmt.Println(name, age)
 	}
+
+	// for range over an OrderedMap (insertion order, every run)
+	ordered := orderedmap.New[string, int]()
+	ordered.Set("Alice", 25)
+	ordered.Set("Bob", 30)
+	for name, age := range ordered.All() {
+		fmt.Println(name, age)
+	}
 }
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"panics"
 	"sync"
 	"time"
 )
@@ -143,6 +144,12 @@ func main() {
 	fmt.Println("\n=== 5. Panic and Recover ===")
 	doPanic() // safe panic handling
 
+	// panics.SafeCall turns the panic into an error instead of printing it
+	err := panics.SafeCall(func() error {
+		panic("Something went wrong again!")
+	})
+	fmt.Println("SafeCall returned:", err)
+
 	fmt.Println("\n=== 6. Mutex ===")
 	var mu sync.Mutex
 	counter := 0
@@ -161,7 +168,7 @@ func main() {
 	fmt.Println("Final counter value:", counter)
 
 	fmt.Println("\n=== 7. Blank Identifier _ ===")
-	_, err := fmt.Println("This value will be ignored")
+	_, err = fmt.Println("This value will be ignored")
 	fmt.Println("Error ignored:", err)
 
 	fmt.Println("\n=== 8. Type Switch ===")
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"mathutil" // import our custom package
+	"orderedmap"
 )
 
 func main() {
@@ -24,11 +25,11 @@ func main() {
 	}
 	fmt.Println("Sum of slice:", total)
 
-	ages := map[string]int{
-		"Alice": 30,
-		"Bob":   25,
-	}
-	for name, age := range ages {
+	// OrderedMap keeps insertion order, so the output is the same on every run
+	ages := orderedmap.New[string, int]()
+	ages.Set("Alice", 30)
+	ages.Set("Bob", 25)
+	for name, age := range ages.All() {
 		fmt.Println(name, "is", age, "years old")
 	}
 }
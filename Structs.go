@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"table"
+)
 
 // Define a struct
 type Person struct {
@@ -22,5 +25,14 @@ func main() {
 	ptr := &p
 	ptr.Age = 31
 	ptr.Greet()
+
+	// Slice of structs as a table: one column per field
+	people := []Person{p, {Name: "Bob", Age: 25}}
+	t, err := table.FromStructs(people)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Print(t)
 }
 
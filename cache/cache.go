@@ -0,0 +1,176 @@
+// Package cache provides size-limited LRU and LFU caches with optional TTL.
+//
+// LRU and LFU are not safe for concurrent use on their own; wrap them with
+// Synchronized, which guards every call with a sync.Mutex as in Mutex.go.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is the behaviour shared by LRU, LFU and Synchronized.
+type Cache[K comparable, V any] interface {
+	// Get returns the value for key. Expired entries are never returned.
+	Get(key K) (V, bool)
+	// Set stores value under key with the default TTL.
+	Set(key K, value V)
+	// SetWithTTL stores value under key, expiring after ttl (0 = never).
+	SetWithTTL(key K, value V, ttl time.Duration)
+	// Delete removes key and reports whether it was present.
+	Delete(key K) bool
+	// Len returns the number of stored entries, including expired ones
+	// that have not been purged yet.
+	Len() int
+	// Purge drops every expired entry.
+	Purge()
+	// Stats returns the hit, miss and eviction counters.
+	Stats() Stats
+}
+
+// Reason says why an entry left the cache.
+type Reason int
+
+const (
+	Evicted Reason = iota // pushed out to respect Capacity
+	Expired               // its TTL ran out
+	Deleted               // removed by Delete
+)
+
+// Options configures a cache.
+type Options[K comparable, V any] struct {
+	// Capacity is the maximum number of entries; it must be positive.
+	Capacity int
+	// TTL is the default time-to-live used by Set; 0 means entries never expire.
+	TTL time.Duration
+	// OnEvict, if set, is called whenever an entry leaves the cache.
+	OnEvict func(key K, value V, reason Reason)
+	// Now replaces time.Now, mostly for tests.
+	Now func() time.Time
+}
+
+// Stats counts cache activity since creation.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64 // removals to respect Capacity
+	Expirations uint64 // removals of expired entries
+}
+
+// ----------------------
+// 1. Shared plumbing
+// ----------------------
+
+// entry is the bookkeeping stored for every key.
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero means no expiry
+}
+
+// base holds what LRU and LFU have in common.
+type base[K comparable, V any] struct {
+	opts  Options[K, V]
+	stats Stats
+}
+
+func newBase[K comparable, V any](opts Options[K, V]) base[K, V] {
+	if opts.Capacity < 1 {
+		panic("cache: capacity must be positive")
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return base[K, V]{opts: opts}
+}
+
+func (b *base[K, V]) deadline(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return b.opts.Now().Add(ttl)
+}
+
+func (b *base[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expires.IsZero() && !b.opts.Now().Before(e.expires)
+}
+
+// evictionReason classifies the removal of e to make room for a new entry:
+// an entry whose TTL already ran out counts as expired, not evicted.
+func (b *base[K, V]) evictionReason(e *entry[K, V]) Reason {
+	if b.expired(e) {
+		return Expired
+	}
+	return Evicted
+}
+
+// removed updates the counters and fires OnEvict for an entry that left the cache.
+func (b *base[K, V]) removed(e *entry[K, V], reason Reason) {
+	switch reason {
+	case Evicted:
+		b.stats.Evictions++
+	case Expired:
+		b.stats.Expirations++
+	}
+	if b.opts.OnEvict != nil {
+		b.opts.OnEvict(e.key, e.value, reason)
+	}
+}
+
+// ----------------------
+// 2. Concurrency-safe wrapper
+// ----------------------
+
+// Synchronized guards a Cache with a mutex.
+// OnEvict callbacks run while the lock is held and must not call back into the cache.
+type Synchronized[K comparable, V any] struct {
+	mu sync.Mutex
+	c  Cache[K, V]
+}
+
+// NewSynchronized wraps c. c must not be used directly afterwards.
+func NewSynchronized[K comparable, V any](c Cache[K, V]) *Synchronized[K, V] {
+	return &Synchronized[K, V]{c: c}
+}
+
+func (s *Synchronized[K, V]) Get(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Get(key)
+}
+
+func (s *Synchronized[K, V]) Set(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.Set(key, value)
+}
+
+func (s *Synchronized[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.SetWithTTL(key, value, ttl)
+}
+
+func (s *Synchronized[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Delete(key)
+}
+
+func (s *Synchronized[K, V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Len()
+}
+
+func (s *Synchronized[K, V]) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.Purge()
+}
+
+func (s *Synchronized[K, V]) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Stats()
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"pqueue"
+	"time"
+)
+
+// LFU evicts the least frequently used entry when full; ties go to the
+// entry that was used least recently.
+type LFU[K comparable, V any] struct {
+	base[K, V]
+	heap  *pqueue.PriorityQueue[*lfuEntry[K, V]]
+	items map[K]*pqueue.Handle[*lfuEntry[K, V]]
+	clock uint64 // incremented on every access, breaks frequency ties
+}
+
+type lfuEntry[K comparable, V any] struct {
+	entry[K, V]
+	uses     uint64
+	lastUsed uint64
+}
+
+var _ Cache[string, int] = (*LFU[string, int])(nil)
+
+// NewLFU returns an empty LFU cache.
+func NewLFU[K comparable, V any](opts Options[K, V]) *LFU[K, V] {
+	return &LFU[K, V]{
+		base: newBase(opts),
+		heap: pqueue.New(func(a, b *lfuEntry[K, V]) bool {
+			if a.uses != b.uses {
+				return a.uses < b.uses
+			}
+			return a.lastUsed < b.lastUsed
+		}),
+		items: make(map[K]*pqueue.Handle[*lfuEntry[K, V]]),
+	}
+}
+
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	var zero V
+	h, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return zero, false
+	}
+	e := h.Value()
+	if c.expired(&e.entry) {
+		c.remove(h, Expired)
+		c.stats.Misses++
+		return zero, false
+	}
+	c.touch(h)
+	c.stats.Hits++
+	return e.value, true
+}
+
+func (c *LFU[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.opts.TTL)
+}
+
+func (c *LFU[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	if h, ok := c.items[key]; ok {
+		e := h.Value()
+		e.value, e.expires = value, c.deadline(ttl)
+		c.touch(h)
+		return
+	}
+	if c.heap.Len() >= c.opts.Capacity {
+		victim, _ := c.heap.Peek()
+		c.remove(c.items[victim.key], c.evictionReason(&victim.entry))
+	}
+	c.clock++
+	e := &lfuEntry[K, V]{
+		entry:    entry[K, V]{key: key, value: value, expires: c.deadline(ttl)},
+		uses:     1,
+		lastUsed: c.clock,
+	}
+	c.items[key] = c.heap.Push(e)
+}
+
+func (c *LFU[K, V]) Delete(key K) bool {
+	h, ok := c.items[key]
+	if ok {
+		c.remove(h, Deleted)
+	}
+	return ok
+}
+
+func (c *LFU[K, V]) Len() int { return c.heap.Len() }
+
+func (c *LFU[K, V]) Purge() {
+	for _, h := range c.items {
+		if c.expired(&h.Value().entry) {
+			c.remove(h, Expired)
+		}
+	}
+}
+
+func (c *LFU[K, V]) Stats() Stats { return c.stats }
+
+// touch records one more use of the entry behind h.
+func (c *LFU[K, V]) touch(h *pqueue.Handle[*lfuEntry[K, V]]) {
+	e := h.Value()
+	c.clock++
+	e.uses++
+	e.lastUsed = c.clock
+	c.heap.Update(h, e)
+}
+
+func (c *LFU[K, V]) remove(h *pqueue.Handle[*lfuEntry[K, V]], reason Reason) {
+	e := h.Value()
+	c.heap.Remove(h)
+	delete(c.items, e.key)
+	c.removed(&e.entry, reason)
+}
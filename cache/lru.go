@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"linkedlist"
+	"time"
+)
+
+// LRU evicts the least recently used entry when full.
+type LRU[K comparable, V any] struct {
+	base[K, V]
+	order linkedlist.List[*entry[K, V]] // front = most recently used
+	items map[K]*linkedlist.Element[*entry[K, V]]
+}
+
+var _ Cache[string, int] = (*LRU[string, int])(nil)
+
+// NewLRU returns an empty LRU cache.
+func NewLRU[K comparable, V any](opts Options[K, V]) *LRU[K, V] {
+	return &LRU[K, V]{
+		base:  newBase(opts),
+		items: make(map[K]*linkedlist.Element[*entry[K, V]]),
+	}
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	var zero V
+	el, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return zero, false
+	}
+	e := el.Value
+	if c.expired(e) {
+		c.remove(el, Expired)
+		c.stats.Misses++
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	c.stats.Hits++
+	return e.value, true
+}
+
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.opts.TTL)
+}
+
+func (c *LRU[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	if el, ok := c.items[key]; ok {
+		e := el.Value
+		e.value, e.expires = value, c.deadline(ttl)
+		c.order.MoveToFront(el)
+		return
+	}
+	if c.order.Len() >= c.opts.Capacity {
+		back := c.order.Back()
+		c.remove(back, c.evictionReason(back.Value))
+	}
+	e := &entry[K, V]{key: key, value: value, expires: c.deadline(ttl)}
+	c.items[key] = c.order.PushFront(e)
+}
+
+func (c *LRU[K, V]) Delete(key K) bool {
+	el, ok := c.items[key]
+	if ok {
+		c.remove(el, Deleted)
+	}
+	return ok
+}
+
+func (c *LRU[K, V]) Len() int { return c.order.Len() }
+
+func (c *LRU[K, V]) Purge() {
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if c.expired(el.Value) {
+			c.remove(el, Expired)
+		}
+		el = next
+	}
+}
+
+func (c *LRU[K, V]) Stats() Stats { return c.stats }
+
+func (c *LRU[K, V]) remove(el *linkedlist.Element[*entry[K, V]], reason Reason) {
+	e := c.order.Remove(el)
+	delete(c.items, e.key)
+	c.removed(e, reason)
+}
@@ -0,0 +1,164 @@
+// Package disjointset provides a generic union-find structure for
+// clustering and connectivity problems.
+//
+// Elements are mapped to dense indices internally, so Find and Union run in
+// nearly constant amortized time (inverse Ackermann) thanks to path
+// compression and union by rank. The zero value is an empty structure
+// ready to use.
+package disjointset
+
+import "iter"
+
+// DisjointSet partitions a collection of values into disjoint sets.
+type DisjointSet[T comparable] struct {
+	index  map[T]int
+	items  []T
+	parent []int
+	rank   []uint8
+	size   []int // valid for roots only
+	next   []int // circular list of each set's members, for enumeration
+	sets   int
+}
+
+// New returns a structure in which every item is its own singleton set.
+func New[T comparable](items ...T) *DisjointSet[T] {
+	d := &DisjointSet[T]{index: make(map[T]int, len(items))}
+	for _, v := range items {
+		d.Add(v)
+	}
+	return d
+}
+
+// ----------------------
+// 1. Building sets
+// ----------------------
+
+// Add inserts v as a singleton set. It returns false if v was already present.
+func (d *DisjointSet[T]) Add(v T) bool {
+	if _, ok := d.index[v]; ok {
+		return false
+	}
+	if d.index == nil {
+		d.index = make(map[T]int)
+	}
+	i := len(d.items)
+	d.index[v] = i
+	d.items = append(d.items, v)
+	d.parent = append(d.parent, i)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.next = append(d.next, i)
+	d.sets++
+	return true
+}
+
+// Union merges the sets containing a and b, adding either one first if it
+// is missing. It returns false if they were already in the same set.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.Add(a)
+	d.Add(b)
+	ra, rb := d.root(d.index[a]), d.root(d.index[b])
+	if ra == rb {
+		return false
+	}
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	d.next[ra], d.next[rb] = d.next[rb], d.next[ra] // splice the two member rings
+	d.sets--
+	return true
+}
+
+// ----------------------
+// 2. Queries
+// ----------------------
+
+// Has reports whether v has been added.
+func (d *DisjointSet[T]) Has(v T) bool {
+	_, ok := d.index[v]
+	return ok
+}
+
+// Find returns the representative of v's set. Two values are in the same
+// set exactly when their representatives are equal. ok is false if v was
+// never added.
+func (d *DisjointSet[T]) Find(v T) (rep T, ok bool) {
+	i, ok := d.index[v]
+	if !ok {
+		return rep, false
+	}
+	return d.items[d.root(i)], true
+}
+
+// Connected reports whether a and b are in the same set.
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	i, ok1 := d.index[a]
+	j, ok2 := d.index[b]
+	return ok1 && ok2 && d.root(i) == d.root(j)
+}
+
+// Size returns the number of members in v's set, or 0 if v was never added.
+func (d *DisjointSet[T]) Size(v T) int {
+	i, ok := d.index[v]
+	if !ok {
+		return 0
+	}
+	return d.size[d.root(i)]
+}
+
+// Len returns the total number of values.
+func (d *DisjointSet[T]) Len() int { return len(d.items) }
+
+// Components returns the number of disjoint sets.
+func (d *DisjointSet[T]) Components() int { return d.sets }
+
+// Members iterates over the values in v's set, starting with v itself.
+// It yields nothing if v was never added.
+func (d *DisjointSet[T]) Members(v T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		start, ok := d.index[v]
+		if !ok {
+			return
+		}
+		for i := start; ; {
+			if !yield(d.items[i]) {
+				return
+			}
+			if i = d.next[i]; i == start {
+				return
+			}
+		}
+	}
+}
+
+// Sets returns every set as a slice of its members, in order of each
+// set's first-added member.
+func (d *DisjointSet[T]) Sets() [][]T {
+	out := make([][]T, 0, d.sets)
+	slot := make(map[int]int, d.sets) // root -> position in out
+	for i, v := range d.items {
+		r := d.root(i)
+		k, ok := slot[r]
+		if !ok {
+			k = len(out)
+			slot[r] = k
+			out = append(out, make([]T, 0, d.size[r]))
+		}
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// root returns the root of i's tree, halving the path on the way up.
+func (d *DisjointSet[T]) root(i int) int {
+	for d.parent[i] != i {
+		d.parent[i] = d.parent[d.parent[i]]
+		i = d.parent[i]
+	}
+	return i
+}
@@ -0,0 +1,10 @@
+package errcode
+
+// Shared catalog. Codes are stable: never rename or reuse one.
+var (
+	// MathDivZero: parameter {dividend}.
+	MathDivZero = Define("MATH_DIV_ZERO", "cannot divide {dividend} by zero")
+
+	// PersonAgeNegative: parameters {name} and {age}.
+	PersonAgeNegative = Define("PERSON_AGE_NEGATIVE", "age of {name} cannot be negative (got {age})")
+)
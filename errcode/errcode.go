@@ -0,0 +1,182 @@
+// Package errcode is a catalog of errors with stable codes.
+//
+// Support matches on codes such as MATH_DIV_ZERO, never on message text,
+// so messages can be reworded or translated without breaking anyone.
+package errcode
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ----------------------
+// 1. Codes and errors
+// ----------------------
+
+// Code is a stable, machine-readable error identifier.
+type Code string
+
+// Error is a catalog error. The value returned by Define acts as a sentinel;
+// With returns copies carrying parameters that still match it under errors.Is.
+type Error struct {
+	Code   Code
+	Params map[string]any
+}
+
+// Error renders the default English message. Parameters not set with
+// With render as <name>.
+func (e *Error) Error() string {
+	return render(lookup(e.Code, ""), e.Params)
+}
+
+// Is matches any *Error with the same code, regardless of parameters.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// With returns a copy of e with key set to value.
+func (e *Error) With(key string, value any) *Error {
+	params := make(map[string]any, len(e.Params)+1)
+	for k, v := range e.Params {
+		params[k] = v
+	}
+	params[key] = value
+	return &Error{Code: e.Code, Params: params}
+}
+
+// Localize renders the message in lang ("es", "fr-CA", ...),
+// falling back to the base language and then to English.
+func (e *Error) Localize(lang string) string {
+	return render(lookup(e.Code, lang), e.Params)
+}
+
+// ----------------------
+// 2. The catalog
+// ----------------------
+
+var (
+	mu           sync.RWMutex
+	templates    = map[Code]string{}            // code -> English template
+	translations = map[string]map[Code]string{} // lang -> code -> template
+)
+
+// Define registers code with its English message template and returns the
+// sentinel error. Templates name parameters in braces: "cannot divide {dividend} by zero".
+// Defining the same code twice panics, since codes must be unique.
+func Define(code Code, template string) *Error {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := templates[code]; dup {
+		panic(fmt.Sprintf("errcode: %s defined twice", code))
+	}
+	templates[code] = template
+	return &Error{Code: code}
+}
+
+// Codes returns every defined code in sorted order.
+func Codes() []Code {
+	mu.RLock()
+	defer mu.RUnlock()
+	codes := make([]Code, 0, len(templates))
+	for c := range templates {
+		codes = append(codes, c)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
+
+func lookup(code Code, lang string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	for lang != "" {
+		if t, ok := translations[lang][code]; ok {
+			return t
+		}
+		i := strings.LastIndexAny(lang, "-_")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	if t, ok := templates[code]; ok {
+		return t
+	}
+	return string(code)
+}
+
+// render fills each {name} in template from params. A parameter with no
+// value renders as <name>, so a bare sentinel still reads sensibly:
+// "cannot divide <dividend> by zero".
+func render(template string, params map[string]any) string {
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(template, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(template[open:], '}')
+		if end < 0 {
+			break
+		}
+		end += open
+		b.WriteString(template[:open])
+		name := template[open+1 : end]
+		if v, ok := params[name]; ok {
+			fmt.Fprint(&b, v)
+		} else {
+			b.WriteString("<" + name + ">")
+		}
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+// ----------------------
+// 3. Translations
+// ----------------------
+
+//go:embed locales/*.json
+var builtin embed.FS
+
+func init() {
+	if err := LoadTranslations(builtin, "locales"); err != nil {
+		panic(err)
+	}
+}
+
+// LoadTranslations reads every <lang>.json file in dir of fsys.
+// Each file maps codes to message templates. Later loads override earlier ones.
+func LoadTranslations(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		var msgs map[Code]string
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return fmt.Errorf("errcode: %s: %w", name, err)
+		}
+		lang := strings.TrimSuffix(path.Base(name), ".json")
+		mu.Lock()
+		if translations[lang] == nil {
+			translations[lang] = map[Code]string{}
+		}
+		for c, t := range msgs {
+			translations[lang][c] = t
+		}
+		mu.Unlock()
+	}
+	return nil
+}
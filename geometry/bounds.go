@@ -0,0 +1,186 @@
+package geometry
+
+import "math"
+
+// AABB is an axis-aligned bounding box, the cheap first test before any
+// exact collision check. Boxes are closed: a box touching another one
+// intersects it.
+type AABB struct {
+	Min, Max Point
+}
+
+// BoxAround returns the smallest box holding points. It returns the zero
+// box for no points.
+func BoxAround(points ...Point) AABB {
+	if len(points) == 0 {
+		return AABB{}
+	}
+	b := AABB{points[0], points[0]}
+	for _, p := range points[1:] {
+		b.Min = Point{min(b.Min.X, p.X), min(b.Min.Y, p.Y)}
+		b.Max = Point{max(b.Max.X, p.X), max(b.Max.Y, p.Y)}
+	}
+	return b
+}
+
+// Width returns the horizontal extent.
+func (b AABB) Width() float64 { return b.Max.X - b.Min.X }
+
+// Height returns the vertical extent.
+func (b AABB) Height() float64 { return b.Max.Y - b.Min.Y }
+
+// Center returns the middle of the box.
+func (b AABB) Center() Point { return Point{(b.Min.X + b.Max.X) / 2, (b.Min.Y + b.Max.Y) / 2} }
+
+// Contains reports whether p lies in b, edges included.
+func (b AABB) Contains(p Point) bool {
+	return b.Min.X <= p.X && p.X <= b.Max.X && b.Min.Y <= p.Y && p.Y <= b.Max.Y
+}
+
+// ContainsBox reports whether o lies entirely in b.
+func (b AABB) ContainsBox(o AABB) bool {
+	return b.Contains(o.Min) && b.Contains(o.Max)
+}
+
+// Intersects reports whether b and o share any point.
+func (b AABB) Intersects(o AABB) bool {
+	return b.Min.X <= o.Max.X && o.Min.X <= b.Max.X && b.Min.Y <= o.Max.Y && o.Min.Y <= b.Max.Y
+}
+
+// Dist returns the distance from p to the nearest point of b, or 0 if b
+// contains p.
+func (b AABB) Dist(p Point) float64 {
+	dx := max(b.Min.X-p.X, 0, p.X-b.Max.X)
+	dy := max(b.Min.Y-p.Y, 0, p.Y-b.Max.Y)
+	return math.Hypot(dx, dy)
+}
+
+// Union returns the smallest box holding both b and o.
+func (b AABB) Union(o AABB) AABB {
+	return AABB{
+		Min: Point{min(b.Min.X, o.Min.X), min(b.Min.Y, o.Min.Y)},
+		Max: Point{max(b.Max.X, o.Max.X), max(b.Max.Y, o.Max.Y)},
+	}
+}
+
+// Expand returns b grown by d on every side.
+func (b AABB) Expand(d float64) AABB {
+	return AABB{b.Min.Sub(Point{d, d}), b.Max.Add(Point{d, d})}
+}
+
+// ----------------------
+// Bounds and point tests
+// ----------------------
+
+// centredBox returns the box of half-extents rx, ry around c.
+func centredBox(c Point, rx, ry float64) AABB {
+	return AABB{Point{c.X - rx, c.Y - ry}, Point{c.X + rx, c.Y + ry}}
+}
+
+// Bounds returns the rectangle's bounding box, which is the rectangle itself.
+func (r Rectangle) Bounds() AABB { return centredBox(r.Pos, r.Width/2, r.Height/2) }
+
+// Contains reports whether p lies in the rectangle, edges included.
+func (r Rectangle) Contains(p Point) bool { return r.Bounds().Contains(p) }
+
+// Bounds returns the square's bounding box, which is the square itself.
+func (s Square) Bounds() AABB { return centredBox(s.Pos, s.Side/2, s.Side/2) }
+
+// Contains reports whether p lies in the square, edges included.
+func (s Square) Contains(p Point) bool { return s.Bounds().Contains(p) }
+
+// Bounds returns the circle's bounding box.
+func (c Circle) Bounds() AABB { return centredBox(c.Pos, c.Radius, c.Radius) }
+
+// Contains reports whether p lies in the circle, edge included.
+func (c Circle) Contains(p Point) bool {
+	d := p.Sub(c.Pos)
+	return d.X*d.X+d.Y*d.Y <= c.Radius*c.Radius
+}
+
+// Bounds returns the ellipse's bounding box.
+func (e Ellipse) Bounds() AABB { return centredBox(e.Pos, e.RX, e.RY) }
+
+// Contains reports whether p lies in the ellipse, edge included.
+func (e Ellipse) Contains(p Point) bool {
+	dx, dy := (p.X-e.Pos.X)/e.RX, (p.Y-e.Pos.Y)/e.RY
+	return dx*dx+dy*dy <= 1
+}
+
+// Bounds returns the triangle's bounding box.
+func (t Triangle) Bounds() AABB { return BoxAround(t.Outline()...) }
+
+// Contains reports whether p lies in the triangle, edges included.
+func (t Triangle) Contains(p Point) bool { return polygonContains(t.Outline(), p) }
+
+// Bounds returns the polygon's bounding box.
+func (p RegularPolygon) Bounds() AABB { return BoxAround(p.Outline()...) }
+
+// Contains reports whether q lies in the polygon, edges included.
+func (p RegularPolygon) Contains(q Point) bool { return polygonContains(p.Outline(), q) }
+
+// Bounds returns the polygon's bounding box.
+func (p Polygon) Bounds() AABB { return BoxAround(p.Outline()...) }
+
+// Contains reports whether q lies in the polygon, edges included.
+func (p Polygon) Contains(q Point) bool { return polygonContains(p.Outline(), q) }
+
+// ----------------------
+// Outlines
+// ----------------------
+
+// Outline returns the corners in world coordinates, counter-clockwise
+// from the bottom left.
+func (r Rectangle) Outline() []Point { return boxOutline(r.Bounds()) }
+
+// Outline returns the corners in world coordinates, counter-clockwise
+// from the bottom left.
+func (s Square) Outline() []Point { return boxOutline(s.Bounds()) }
+
+// Outline returns the vertices in world coordinates.
+func (t Triangle) Outline() []Point { return translate(t.Vertices[:], t.Pos) }
+
+// Outline returns the vertices in world coordinates.
+func (p RegularPolygon) Outline() []Point { return translate(p.Vertices(), p.Pos) }
+
+// Outline returns the vertices in world coordinates.
+func (p Polygon) Outline() []Point { return translate(p.Vertices, p.Pos) }
+
+func boxOutline(b AABB) []Point {
+	return []Point{b.Min, {b.Max.X, b.Min.Y}, b.Max, {b.Min.X, b.Max.Y}}
+}
+
+func translate(vs []Point, d Point) []Point {
+	out := make([]Point, len(vs))
+	for i, v := range vs {
+		out[i] = v.Add(d)
+	}
+	return out
+}
+
+// polygonContains reports whether p lies in the simple polygon vs, edges
+// included, by counting crossings of a ray going right from p.
+func polygonContains(vs []Point, p Point) bool {
+	inside := false
+	for i, a := range vs {
+		b := vs[(i+1)%len(vs)]
+		if cross(a, b, p) == 0 && onSegment(a, b, p) {
+			return true
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ellipseOutline approximates an ellipse by an inscribed polygon.
+func ellipseOutline(e Ellipse) []Point {
+	const n = 64
+	out := make([]Point, n)
+	for i := range out {
+		a := 2 * math.Pi * float64(i) / n
+		out[i] = Point{e.Pos.X + e.RX*math.Cos(a), e.Pos.Y + e.RY*math.Sin(a)}
+	}
+	return out
+}
@@ -0,0 +1,178 @@
+package geometry
+
+import "math"
+
+// Collider is a positioned shape that supports collision tests. Every
+// shape in this package implements it.
+type Collider interface {
+	Shape
+	Bounds() AABB
+	Contains(p Point) bool
+}
+
+// Intersects reports whether a and b overlap, touching included.
+//
+// Circles, rectangles, squares, triangles, regular polygons and convex
+// polygons are tested exactly, convex pairs with the separating axis
+// theorem. Non-convex polygons are tested edge by edge. Ellipses are
+// approximated by an inscribed 64-gon. Colliders from other packages are
+// compared by their bounding boxes only.
+func Intersects(a, b Collider) bool {
+	if !a.Bounds().Intersects(b.Bounds()) {
+		return false
+	}
+	ca, okA := solidOf(a)
+	cb, okB := solidOf(b)
+	if !okA || !okB {
+		return true // the boxes overlap; nothing finer is known
+	}
+	switch {
+	case ca.box && cb.box:
+		return true // axis-aligned boxes: the bounds test was exact
+	case ca.circle && cb.circle:
+		r := ca.r + cb.r
+		d := ca.c.Sub(cb.c)
+		return d.X*d.X+d.Y*d.Y <= r*r
+	case ca.circle:
+		return circlePolygon(ca, cb)
+	case cb.circle:
+		return circlePolygon(cb, ca)
+	case ca.convex && cb.convex:
+		return !separated(ca.pts, cb.pts) && !separated(cb.pts, ca.pts)
+	}
+	return polygonsOverlap(ca.pts, cb.pts)
+}
+
+// Distance returns the distance from p to the nearest point of s, or 0 if
+// s contains p. Like Intersects, it approximates ellipses and falls back to
+// the bounding box for colliders from other packages.
+func Distance(s Collider, p Point) float64 {
+	if s.Contains(p) {
+		return 0
+	}
+	c, ok := solidOf(s)
+	if !ok {
+		return s.Bounds().Dist(p)
+	}
+	if c.circle {
+		return max(p.Dist(c.c)-c.r, 0)
+	}
+	d := math.Inf(1)
+	for i, a := range c.pts {
+		d = min(d, segmentDist(a, c.pts[(i+1)%len(c.pts)], p))
+	}
+	return d
+}
+
+// solid is a shape reduced to what the collision tests need: either a
+// circle or a polygon in world coordinates.
+type solid struct {
+	circle bool
+	c      Point
+	r      float64
+
+	pts    []Point
+	convex bool
+	box    bool // pts is an axis-aligned rectangle
+}
+
+func solidOf(s Collider) (solid, bool) {
+	switch s := s.(type) {
+	case Circle:
+		return solid{circle: true, c: s.Pos, r: s.Radius}, true
+	case Rectangle:
+		return solid{pts: s.Outline(), convex: true, box: true}, true
+	case Square:
+		return solid{pts: s.Outline(), convex: true, box: true}, true
+	case Triangle:
+		return solid{pts: s.Outline(), convex: true}, true
+	case RegularPolygon:
+		return solid{pts: s.Outline(), convex: true}, true
+	case Polygon:
+		pts := s.Outline()
+		return solid{pts: pts, convex: isConvex(pts)}, true
+	case Ellipse:
+		return solid{pts: ellipseOutline(s), convex: true}, true
+	}
+	return solid{}, false
+}
+
+// circlePolygon tests a circle against any simple polygon: they overlap if
+// the centre is inside or some edge comes within the radius.
+func circlePolygon(c, p solid) bool {
+	if polygonContains(p.pts, c.c) {
+		return true
+	}
+	for i, a := range p.pts {
+		if segmentDist(a, p.pts[(i+1)%len(p.pts)], c.c) <= c.r {
+			return true
+		}
+	}
+	return false
+}
+
+// separated reports whether some edge normal of a separates a from b:
+// the separating axis theorem says two convex polygons are disjoint
+// exactly when such an axis exists among the edge normals of either one.
+func separated(a, b []Point) bool {
+	for i, p := range a {
+		q := a[(i+1)%len(a)]
+		axis := Point{p.Y - q.Y, q.X - p.X}
+		minA, maxA := project(a, axis)
+		minB, maxB := project(b, axis)
+		if maxA < minB || maxB < minA {
+			return true
+		}
+	}
+	return false
+}
+
+func project(pts []Point, axis Point) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, p := range pts {
+		d := p.X*axis.X + p.Y*axis.Y
+		lo, hi = min(lo, d), max(hi, d)
+	}
+	return lo, hi
+}
+
+// polygonsOverlap tests two simple polygons of any shape: they overlap if
+// their edges cross or one lies entirely inside the other.
+func polygonsOverlap(a, b []Point) bool {
+	for i := range a {
+		for j := range b {
+			if segmentsIntersect(a[i], a[(i+1)%len(a)], b[j], b[(j+1)%len(b)]) {
+				return true
+			}
+		}
+	}
+	return polygonContains(a, b[0]) || polygonContains(b, a[0])
+}
+
+// segmentDist returns the distance from p to segment ab.
+func segmentDist(a, b, p Point) float64 {
+	ab, ap := b.Sub(a), p.Sub(a)
+	if ab.X == 0 && ab.Y == 0 {
+		return p.Dist(a) // the segment is a point
+	}
+	t := (ap.X*ab.X + ap.Y*ab.Y) / (ab.X*ab.X + ab.Y*ab.Y)
+	t = max(0, min(1, t))
+	return p.Dist(Point{a.X + t*ab.X, a.Y + t*ab.Y})
+}
+
+// isConvex reports whether every turn of the polygon goes the same way.
+func isConvex(pts []Point) bool {
+	sign := 0.0
+	for i, p := range pts {
+		c := cross(p, pts[(i+1)%len(pts)], pts[(i+2)%len(pts)])
+		if c == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = c
+		} else if (c > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+const eps = 1e-9
+
+func circleAt(x, y, r float64) Circle {
+	c := Circle{Radius: r}
+	c.Pos = Point{x, y}
+	return c
+}
+
+func rectAt(x, y, w, h float64) Rectangle {
+	r := Rectangle{Width: w, Height: h}
+	r.Pos = Point{x, y}
+	return r
+}
+
+func squareAt(x, y, side float64) Square {
+	s := Square{Side: side}
+	s.Pos = Point{x, y}
+	return s
+}
+
+func ellipseAt(x, y, rx, ry float64) Ellipse {
+	e := Ellipse{RX: rx, RY: ry}
+	e.Pos = Point{x, y}
+	return e
+}
+
+func hexAt(x, y, side float64) RegularPolygon {
+	p := RegularPolygon{N: 6, Side: side}
+	p.Pos = Point{x, y}
+	return p
+}
+
+func triangle(a, b, c Point) Triangle { return Triangle{Vertices: [3]Point{a, b, c}} }
+
+// uShape is a non-convex polygon with a pocket over 1 < x < 2, 1 < y < 3.
+var uShape = Polygon{Vertices: []Point{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}}}
+
+func TestIntersects(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Collider
+		want bool
+	}{
+		// Circle and circle.
+		{"circles touching", circleAt(0, 0, 1), circleAt(2, 0, 1), true},
+		{"circles apart", circleAt(0, 0, 1), circleAt(2.01, 0, 1), false},
+		{"circle in circle", circleAt(0, 0, 5), circleAt(1, 1, 1), true},
+
+		// Boxes.
+		{"rectangles touching", rectAt(0, 0, 2, 2), rectAt(2, 0, 2, 2), true},
+		{"rectangles apart", rectAt(0, 0, 2, 2), rectAt(2.01, 0, 2, 2), false},
+		{"square in rectangle", rectAt(0, 0, 10, 10), squareAt(1, 1, 1), true},
+
+		// Circle and polygon.
+		{"circle touching rectangle edge", circleAt(2, 0, 1), rectAt(0, 0, 2, 2), true},
+		{"circle off rectangle corner", circleAt(2, 2, 1), rectAt(0, 0, 2, 2), false},
+		{"rectangle in circle", circleAt(0, 0, 10), rectAt(0, 0, 1, 1), true},
+		{"circle in rectangle", rectAt(0, 0, 10, 10), circleAt(1, 1, 1), true},
+		{"circle short of hypotenuse", circleAt(3, 3, 1.4), triangle(Point{0, 0}, Point{4, 0}, Point{0, 4}), false},
+		{"circle over hypotenuse", circleAt(3, 3, 1.5), triangle(Point{0, 0}, Point{4, 0}, Point{0, 4}), true},
+		{"circle in pocket", circleAt(1.5, 2.2, 0.3), uShape, false},
+		{"circle touching pocket walls", uShape, circleAt(1.5, 2.2, 0.5), true},
+
+		// Convex polygons (separating axis theorem).
+		{"triangles sharing a vertex", triangle(Point{0, 0}, Point{2, 0}, Point{0, 2}), triangle(Point{2, 0}, Point{4, 0}, Point{3, 2}), true},
+		{"triangles apart, boxes overlapping", triangle(Point{0, 0}, Point{2, 0}, Point{0, 2}), triangle(Point{2, 2}, Point{2, 1.2}, Point{1.2, 2}), false},
+		{"square beyond hexagon edge", hexAt(0, 0, 1), squareAt(0.7, 0.9, 0.2), false},
+		{"square in hexagon", hexAt(0, 0, 1), squareAt(0.5, 0.5, 0.2), true},
+		{"rectangle in triangle", triangle(Point{0, 0}, Point{4, 0}, Point{0, 4}), rectAt(1, 1, 0.5, 0.5), true},
+
+		// Non-convex polygons (edge by edge).
+		{"square in pocket", uShape, squareAt(1.5, 2.2, 0.6), false},
+		{"rectangle touching pocket floor", rectAt(1.5, 1.5, 0.6, 1), uShape, true},
+		{"square in arm", uShape, squareAt(0.5, 1.5, 0.4), true},
+		{"polygon around polygon", uShape, Polygon{Vertices: []Point{{-1, -1}, {4, -1}, {4, 4}, {-1, 4}}}, true},
+
+		// Ellipses use an inscribed 64-gon whose vertices lie on the axes.
+		{"circle above ellipse", ellipseAt(0, 0, 3, 1), circleAt(0, 2.5, 1), false},
+		{"circle over ellipse top", ellipseAt(0, 0, 3, 1), circleAt(0, 1.9, 1), true},
+		{"circle past ellipse tip", ellipseAt(0, 0, 3, 1), circleAt(3.5, 0, 0.4), false},
+		{"circle over ellipse tip", circleAt(3.3, 0, 0.4), ellipseAt(0, 0, 3, 1), true},
+		{"ellipses crossing", ellipseAt(0, 0, 3, 1), ellipseAt(0, 0, 1, 3), true},
+		{"rectangle in ellipse", ellipseAt(0, 0, 3, 1), rectAt(0, 0, 1, 0.5), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Intersects(tt.a, tt.b); got != tt.want {
+				t.Errorf("Intersects(a, b) = %v, want %v", got, tt.want)
+			}
+			if got := Intersects(tt.b, tt.a); got != tt.want {
+				t.Errorf("Intersects(b, a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		s    Collider
+		p    Point
+		want bool
+	}{
+		{rectAt(0, 0, 2, 2), Point{1, 1}, true}, // corner
+		{rectAt(0, 0, 2, 2), Point{1.01, 0}, false},
+		{squareAt(5, 5, 2), Point{5, 5}, true},
+		{circleAt(0, 0, 1), Point{0, -1}, true}, // edge
+		{circleAt(0, 0, 1), Point{0.8, 0.8}, false},
+		{ellipseAt(0, 0, 3, 1), Point{2.9, 0}, true},
+		{ellipseAt(0, 0, 3, 1), Point{0, 1.1}, false},
+		{triangle(Point{0, 0}, Point{4, 0}, Point{0, 4}), Point{2, 2}, true}, // hypotenuse
+		{triangle(Point{0, 0}, Point{4, 0}, Point{0, 4}), Point{0, 4}, true}, // vertex
+		{triangle(Point{0, 0}, Point{4, 0}, Point{0, 4}), Point{2.1, 2}, false},
+		{hexAt(0, 0, 1), Point{0, 0}, true},
+		{hexAt(0, 0, 1), Point{0.9, 0}, false},
+		{uShape, Point{1.5, 2}, false}, // pocket
+		{uShape, Point{0.5, 2}, true},
+		{uShape, Point{1.5, 1}, true}, // pocket floor
+	}
+	for _, tt := range tests {
+		if got := tt.s.Contains(tt.p); got != tt.want {
+			t.Errorf("%T.Contains(%v) = %v, want %v", tt.s, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	// The constructor rejects repeated vertices; a literal can still have
+	// a zero-length edge.
+	repeated := Polygon{Vertices: []Point{{0, 0}, {0, 0}, {4, 0}, {0, 4}}}
+	tests := []struct {
+		s    Collider
+		p    Point
+		want float64
+	}{
+		{circleAt(0, 0, 1), Point{5, 0}, 4},
+		{circleAt(0, 0, 1), Point{0.5, 0}, 0},
+		{rectAt(0, 0, 2, 2), Point{2, 2}, math.Sqrt2},
+		{rectAt(0, 0, 2, 2), Point{0, -3}, 2},
+		{triangle(Point{0, 0}, Point{4, 0}, Point{0, 4}), Point{3, 3}, math.Sqrt2},
+		{uShape, Point{1.5, 2}, 0.5},
+		{uShape, Point{1.5, 4}, math.Hypot(0.5, 1)},
+		{repeated, Point{-3, -4}, 5},
+		{repeated, Point{1, 1}, 0},
+		{ellipseAt(0, 0, 3, 1), Point{5, 0}, 2}, // a vertex of the 64-gon
+		{ellipseAt(0, 0, 3, 1), Point{0, 3}, 2},
+	}
+	for _, tt := range tests {
+		got := Distance(tt.s, tt.p)
+		if math.IsNaN(got) || math.Abs(got-tt.want) > eps {
+			t.Errorf("Distance(%T, %v) = %g, want %g", tt.s, tt.p, got, tt.want)
+		}
+	}
+
+	// Between the 64-gon's vertices the approximation may overestimate the
+	// distance to the true ellipse, but only slightly.
+	e := ellipseAt(0, 0, 3, 1)
+	for i := range 100 {
+		a := 2 * math.Pi * float64(i) / 100
+		onEdge := Point{3 * math.Cos(a), math.Sin(a)}
+		if d := Distance(e, onEdge); d > 0.01 {
+			t.Errorf("Distance to ellipse edge point %v = %g", onEdge, d)
+		}
+	}
+}
+
+func TestSegmentDist(t *testing.T) {
+	tests := []struct {
+		a, b, p Point
+		want    float64
+	}{
+		{Point{0, 0}, Point{4, 0}, Point{2, 3}, 3},
+		{Point{0, 0}, Point{4, 0}, Point{-3, 4}, 5}, // beyond a
+		{Point{0, 0}, Point{4, 0}, Point{7, 4}, 5},  // beyond b
+		{Point{1, 1}, Point{1, 1}, Point{4, 5}, 5},  // zero length
+		{Point{1, 1}, Point{1, 1}, Point{1, 1}, 0},
+	}
+	for _, tt := range tests {
+		if got := segmentDist(tt.a, tt.b, tt.p); math.IsNaN(got) || math.Abs(got-tt.want) > eps {
+			t.Errorf("segmentDist(%v, %v, %v) = %g, want %g", tt.a, tt.b, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		s    Collider
+		want AABB
+	}{
+		{rectAt(1, 2, 4, 2), AABB{Point{-1, 1}, Point{3, 3}}},
+		{squareAt(1, 1, 2), AABB{Point{0, 0}, Point{2, 2}}},
+		{circleAt(-1, 1, 2), AABB{Point{-3, -1}, Point{1, 3}}},
+		{ellipseAt(0, 0, 3, 1), AABB{Point{-3, -1}, Point{3, 1}}},
+		{triangle(Point{0, 0}, Point{4, 0}, Point{1, 3}), AABB{Point{0, 0}, Point{4, 3}}},
+		{uShape, AABB{Point{0, 0}, Point{3, 3}}},
+		{hexAt(0, 0, 1), AABB{Point{-math.Sqrt(3) / 2, -1}, Point{math.Sqrt(3) / 2, 1}}},
+	}
+	for _, tt := range tests {
+		got := tt.s.Bounds()
+		if got.Min.Dist(tt.want.Min) > eps || got.Max.Dist(tt.want.Max) > eps {
+			t.Errorf("%T.Bounds() = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+
+	// Bounds follow Pos for shapes defined around other points too.
+	tri := triangle(Point{0, 0}, Point{4, 0}, Point{1, 3})
+	tri.Pos = Point{10, 10}
+	if got, want := tri.Bounds(), (AABB{Point{10, 10}, Point{14, 13}}); got != want {
+		t.Errorf("moved triangle Bounds() = %v, want %v", got, want)
+	}
+}
+
+func TestDegenerateRegularPolygon(t *testing.T) {
+	for _, n := range []int{-5, -1, 0, 1, 2} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			p := RegularPolygon{N: n, Side: 1}
+			if vs := p.Vertices(); vs != nil {
+				t.Errorf("Vertices() = %v, want nil", vs)
+			}
+			p.Bounds()
+			p.Contains(Point{})
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Package geometry grows the Shape interface from Interface.go into a set
+// of 2-D shapes with validated constructors.
+//
+// Each shape is a plain struct, so a literal like Circle{Radius: 3} still
+// works; the NewX constructors additionally reject dimensions that make no
+// sense (negative radii, a triangle breaking the triangle inequality, a
+// self-intersecting polygon) and return an error wrapping ErrInvalidShape.
+//
+// Every shape embeds a Placement: a position and a drawing style. Shapes
+// are defined in local coordinates with y pointing up, and Pos translates
+// them. Rectangles, squares, circles, ellipses and regular polygons are
+// centred on their local origin, so for them Pos is the centre.
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Shape is any closed 2-D figure.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+// ErrInvalidShape is wrapped by every constructor error.
+var ErrInvalidShape = errors.New("geometry: invalid shape")
+
+// Placement positions and styles a shape.
+type Placement struct {
+	Pos   Point `json:"pos,omitzero"`
+	Style Style `json:"style,omitzero"`
+}
+
+// Style controls how a shape is drawn. Empty fields fall back to the
+// renderer's defaults.
+type Style struct {
+	Fill        string  `json:"fill,omitempty"`         // any SVG color, e.g. "#a6cee3" or "none"
+	Stroke      string  `json:"stroke,omitempty"`       // outline color
+	StrokeWidth float64 `json:"stroke_width,omitempty"` // outline width in drawing units
+}
+
+// Point is a position in the plane.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Add returns p moved by q.
+func (p Point) Add(q Point) Point { return Point{p.X + q.X, p.Y + q.Y} }
+
+// Sub returns the vector from q to p.
+func (p Point) Sub(q Point) Point { return Point{p.X - q.X, p.Y - q.Y} }
+
+// Dist returns the distance between p and q.
+func (p Point) Dist(q Point) float64 { return math.Hypot(p.X-q.X, p.Y-q.Y) }
+
+// cross returns the z component of the cross product (a-o) x (b-o):
+// positive if o, a, b turn counter-clockwise, negative if clockwise,
+// zero if collinear.
+func cross(o, a, b Point) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// flatTolerance is how flat three points may be, as |cross| over the
+// square of the longest side, before they count as collinear.
+const flatTolerance = 1e-12
+
+// collinear reports whether p, q and r lie on one line, within
+// flatTolerance, so that rounding cannot turn a valid triangle into a
+// degenerate one between construction and decoding.
+func collinear(p, q, r Point) bool {
+	longest := max(p.Dist(q), q.Dist(r), r.Dist(p))
+	return math.Abs(cross(p, q, r)) <= flatTolerance*longest*longest
+}
+
+// checkLength validates a length such as a radius or a side.
+func checkLength(name string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+		return fmt.Errorf("%w: %s must be positive and finite, got %g", ErrInvalidShape, name, v)
+	}
+	return nil
+}
+
+// checkPoint validates a vertex or position.
+func checkPoint(p Point) error {
+	for _, v := range [2]float64{p.X, p.Y} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: point %v is not finite", ErrInvalidShape, p)
+		}
+	}
+	return nil
+}
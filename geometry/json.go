@@ -0,0 +1,207 @@
+package geometry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// A Shape stored in an interface loses its concrete type when encoded with
+// encoding/json, so it cannot be decoded again. JSONShape and Shapes add a
+// "type" field naming the registered type:
+//
+//	{"type":"circle","radius":3,"pos":{"x":9,"y":3}}
+
+// ErrUnknownType is wrapped by errors for shape types that are not registered.
+var ErrUnknownType = errors.New("geometry: unknown shape type")
+
+// ----------------------
+// 1. The registry
+// ----------------------
+
+type decoder func(data []byte) (Shape, error)
+
+// validator is implemented by the built-in shapes. Its errors wrap
+// ErrInvalidShape.
+type validator interface {
+	validate() error
+}
+
+var (
+	regMu    sync.RWMutex
+	decoders = map[string]decoder{}      // name -> decoder
+	names    = map[reflect.Type]string{} // concrete type -> name
+)
+
+func init() {
+	Register[Rectangle]("rectangle")
+	Register[Square]("square")
+	Register[Circle]("circle")
+	Register[Ellipse]("ellipse")
+	Register[Triangle]("triangle")
+	Register[RegularPolygon]("regular_polygon")
+	Register[Polygon]("polygon")
+}
+
+// Register makes shape type S encodable and decodable under name, which is
+// what appears in the "type" field. S must encode to a JSON object.
+// Built-in shapes are checked after decoding just as their NewX
+// constructors check their arguments.
+// Registering the same name or type twice panics.
+func Register[S Shape](name string) {
+	typ := reflect.TypeFor[S]()
+	regMu.Lock()
+	defer regMu.Unlock()
+	if _, dup := decoders[name]; dup {
+		panic(fmt.Sprintf("geometry: shape type %q registered twice", name))
+	}
+	if prev, dup := names[typ]; dup {
+		panic(fmt.Sprintf("geometry: %s already registered as %q", typ, prev))
+	}
+	decoders[name] = func(data []byte) (Shape, error) {
+		var s S
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		// Decoding bypasses the NewX constructors, so rerun their checks.
+		if v, ok := any(s).(validator); ok {
+			if err := v.validate(); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	}
+	names[typ] = name
+}
+
+// Types returns every registered type name in sorted order.
+func Types() []string {
+	regMu.RLock()
+	defer regMu.RUnlock()
+	out := make([]string, 0, len(decoders))
+	for name := range decoders {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// typeName returns the registered name for s. A pointer to a registered
+// type uses the name of the type it points to.
+func typeName(s Shape) (string, error) {
+	typ := reflect.TypeOf(s)
+	regMu.RLock()
+	defer regMu.RUnlock()
+	name, ok := names[typ]
+	if !ok && typ.Kind() == reflect.Pointer {
+		name, ok = names[typ.Elem()]
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: %s is not registered", ErrUnknownType, typ)
+	}
+	return name, nil
+}
+
+// ----------------------
+// 2. Encoding
+// ----------------------
+
+// JSONShape wraps a Shape so that it encodes with a "type" field and
+// decodes back into the same concrete type. A nil Shape encodes as null.
+type JSONShape struct {
+	Shape
+}
+
+// MarshalJSON encodes the shape's own fields with "type" added first.
+func (j JSONShape) MarshalJSON() ([]byte, error) {
+	if j.Shape == nil {
+		return []byte("null"), nil
+	}
+	name, err := typeName(j.Shape)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(j.Shape)
+	if err != nil {
+		return nil, err
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) < 2 || body[0] != '{' {
+		return nil, fmt.Errorf("geometry: shape type %q does not encode to a JSON object", name)
+	}
+	tag, _ := json.Marshal(name)
+	out := make([]byte, 0, len(body)+len(tag)+9)
+	out = append(out, `{"type":`...)
+	out = append(out, tag...)
+	if rest := bytes.TrimSpace(body[1:]); len(rest) > 0 && rest[0] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, body[1:]...), nil
+}
+
+// UnmarshalJSON decodes a shape written by MarshalJSON. It fails if the
+// "type" field is missing or names a type nobody registered.
+func (j *JSONShape) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		j.Shape = nil
+		return nil
+	}
+	var head struct {
+		Type *string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return fmt.Errorf("geometry: decoding shape: %w", err)
+	}
+	if head.Type == nil {
+		return errors.New(`geometry: shape JSON has no "type" field`)
+	}
+	regMu.RLock()
+	decode, ok := decoders[*head.Type]
+	regMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w %q (registered: %v)", ErrUnknownType, *head.Type, Types())
+	}
+	s, err := decode(data)
+	if err != nil {
+		return fmt.Errorf("geometry: decoding %s: %w", *head.Type, err)
+	}
+	j.Shape = s
+	return nil
+}
+
+// Shapes is a slice of shapes that encodes every element as a JSONShape.
+type Shapes []Shape
+
+// MarshalJSON encodes the shapes as a JSON array of typed objects.
+func (s Shapes) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	wrapped := make([]JSONShape, len(s))
+	for i, shape := range s {
+		wrapped[i] = JSONShape{shape}
+	}
+	return json.Marshal(wrapped)
+}
+
+// UnmarshalJSON decodes an array written by MarshalJSON.
+func (s *Shapes) UnmarshalJSON(data []byte) error {
+	var wrapped []JSONShape
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return err
+	}
+	if wrapped == nil {
+		*s = nil
+		return nil
+	}
+	out := make(Shapes, len(wrapped))
+	for i, w := range wrapped {
+		out[i] = w.Shape
+	}
+	*s = out
+	return nil
+}
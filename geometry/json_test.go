@@ -0,0 +1,178 @@
+package geometry
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+// samples has one shape of every registered type, placed and styled.
+func samples() map[string]Shape {
+	tri, _ := NewTriangle(3, 4, 5)
+	tri.Pos = Point{1, 2}
+	poly, _ := NewPolygon(Point{0, 0}, Point{4, 0}, Point{4, 3}, Point{2, 1}, Point{0, 3})
+	poly.Style = Style{Fill: "#eee", Stroke: "red", StrokeWidth: 0.5}
+	hex := hexAt(-3, 4.5, 2)
+	hex.Style.Fill = "none"
+	return map[string]Shape{
+		"rectangle":       rectAt(1, 1, 4, 2),
+		"square":          squareAt(0, 0, 3),
+		"circle":          circleAt(9, 3, 3),
+		"ellipse":         ellipseAt(-1, -1, 3, 1),
+		"triangle":        tri,
+		"regular_polygon": hex,
+		"polygon":         poly,
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	samples := samples()
+	for _, name := range Types() {
+		t.Run(name, func(t *testing.T) {
+			s, ok := samples[name]
+			if !ok {
+				t.Fatalf("no sample shape for registered type %q", name)
+			}
+			data, err := json.Marshal(JSONShape{s})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var head struct{ Type string }
+			if err := json.Unmarshal(data, &head); err != nil || head.Type != name {
+				t.Fatalf("type field = %q in %s", head.Type, data)
+			}
+			var got JSONShape
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("decoding %s: %v", data, err)
+			}
+			if !reflect.DeepEqual(got.Shape, s) {
+				t.Fatalf("round trip = %#v, want %#v", got.Shape, s)
+			}
+
+			// A pointer encodes under the name of the type it points to.
+			ptr := reflect.New(reflect.TypeOf(s))
+			ptr.Elem().Set(reflect.ValueOf(s))
+			pdata, err := json.Marshal(JSONShape{ptr.Interface().(Shape)})
+			if err != nil || string(pdata) != string(data) {
+				t.Fatalf("pointer encodes as %s, %v; want %s", pdata, err, data)
+			}
+		})
+	}
+
+	shapes := Shapes{rectAt(1, 1, 4, 2), nil, circleAt(0, 0, 1)}
+	data, err := json.Marshal(shapes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Shapes
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, shapes) {
+		t.Fatalf("Shapes round trip = %v, want %v", got, shapes)
+	}
+}
+
+func TestJSONRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error // nil: any error will do
+	}{
+		{"unknown type", `{"type":"star","points":5}`, ErrUnknownType},
+		{"missing type", `{"radius":1}`, nil},
+		{"not an object", `[1,2]`, nil},
+		{"wrong field type", `{"type":"circle","radius":"big"}`, nil},
+		{"zero width", `{"type":"rectangle","width":0,"height":2}`, ErrInvalidShape},
+		{"negative height", `{"type":"rectangle","width":1,"height":-2}`, ErrInvalidShape},
+		{"zero side", `{"type":"square","side":0}`, ErrInvalidShape},
+		{"missing radius", `{"type":"circle"}`, ErrInvalidShape},
+		{"negative radius", `{"type":"circle","radius":-1}`, ErrInvalidShape},
+		{"flat ellipse", `{"type":"ellipse","rx":2,"ry":0}`, ErrInvalidShape},
+		{"collinear triangle", `{"type":"triangle","vertices":[{"x":0,"y":0},{"x":1,"y":1},{"x":2,"y":2}]}`, ErrInvalidShape},
+		{"two-sided polygon", `{"type":"regular_polygon","n":2,"side":1}`, ErrInvalidShape},
+		{"negative sides", `{"type":"regular_polygon","n":-1,"side":1}`, ErrInvalidShape},
+		{"zero regular side", `{"type":"regular_polygon","n":5,"side":0}`, ErrInvalidShape},
+		{"crossing polygon", `{"type":"polygon","vertices":[{"x":0,"y":0},{"x":2,"y":2},{"x":2,"y":0},{"x":0,"y":2}]}`, ErrInvalidShape},
+		{"short polygon", `{"type":"polygon","vertices":[{"x":0,"y":0},{"x":1,"y":0}]}`, ErrInvalidShape},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var j JSONShape
+			err := json.Unmarshal([]byte(tt.data), &j)
+			if err == nil {
+				t.Fatalf("decoded %s as %#v", tt.data, j.Shape)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONNull(t *testing.T) {
+	j := JSONShape{circleAt(0, 0, 1)}
+	if err := json.Unmarshal([]byte("null"), &j); err != nil || j.Shape != nil {
+		t.Fatalf("null decodes to %v, %v", j.Shape, err)
+	}
+	if data, err := json.Marshal(JSONShape{}); err != nil || string(data) != "null" {
+		t.Fatalf("nil shape encodes to %s, %v", data, err)
+	}
+
+	s := Shapes{circleAt(0, 0, 1)}
+	if err := json.Unmarshal([]byte("null"), &s); err != nil || s != nil {
+		t.Fatalf("null decodes to %v, %v", s, err)
+	}
+	if data, err := json.Marshal(Shapes(nil)); err != nil || string(data) != "null" {
+		t.Fatalf("nil Shapes encodes to %s, %v", data, err)
+	}
+}
+
+// TestNearlyFlatTriangles checks that whatever NewTriangle accepts also
+// survives decoding, however close it comes to breaking the triangle
+// inequality.
+func TestNearlyFlatTriangles(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	accepted := 0
+	for range 20000 {
+		a, b := 0.1+rng.Float64()*10, 0.1+rng.Float64()*10
+		c := (a + b) * (1 - rng.Float64()*1e-14)
+		tri, err := NewTriangle(a, b, c)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidShape) {
+				t.Fatalf("NewTriangle(%g, %g, %g): %v", a, b, c, err)
+			}
+			continue
+		}
+		accepted++
+		data, err := json.Marshal(JSONShape{tri})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got JSONShape
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("NewTriangle(%g, %g, %g) accepted but decoding failed: %v", a, b, c, err)
+		}
+	}
+	if accepted == 0 {
+		t.Fatal("every triangle was rejected")
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"name": func() { Register[Polygon]("circle") },
+		"type": func() { Register[Circle]("round") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("no panic")
+				}
+			}()
+			f()
+		})
+	}
+}
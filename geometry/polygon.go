@@ -0,0 +1,226 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// ----------------------
+// 1. Triangle
+// ----------------------
+
+// Triangle is given by its three vertices.
+type Triangle struct {
+	Vertices [3]Point `json:"vertices"`
+	Placement
+}
+
+// NewTriangle returns a triangle with sides a, b and c. It is placed with
+// the c side along the x axis starting at the origin.
+func NewTriangle(a, b, c float64) (Triangle, error) {
+	for _, s := range [3]struct {
+		name string
+		v    float64
+	}{{"a", a}, {"b", b}, {"c", c}} {
+		if err := checkLength("side "+s.name, s.v); err != nil {
+			return Triangle{}, err
+		}
+	}
+	if a+b <= c || a+c <= b || b+c <= a {
+		return Triangle{}, fmt.Errorf("%w: sides %g, %g, %g break the triangle inequality", ErrInvalidShape, a, b, c)
+	}
+	// Vertex C sits at distance b from the origin and a from (c, 0).
+	x := (b*b + c*c - a*a) / (2 * c)
+	y := math.Sqrt(max(b*b-x*x, 0))
+	// Nearly flat triangles can round to collinear vertices; check them the
+	// same way validate does.
+	return TriangleFromPoints(Point{0, 0}, Point{c, 0}, Point{x, y})
+}
+
+// TriangleFromPoints returns the triangle p, q, r. The points must not be
+// collinear, or so close to it that rounding could make them so.
+func TriangleFromPoints(p, q, r Point) (Triangle, error) {
+	for _, v := range [3]Point{p, q, r} {
+		if err := checkPoint(v); err != nil {
+			return Triangle{}, err
+		}
+	}
+	if collinear(p, q, r) {
+		return Triangle{}, fmt.Errorf("%w: points %v, %v, %v are collinear", ErrInvalidShape, p, q, r)
+	}
+	return Triangle{Vertices: [3]Point{p, q, r}}, nil
+}
+
+func (t Triangle) validate() error {
+	v := t.Vertices
+	if _, err := TriangleFromPoints(v[0], v[1], v[2]); err != nil {
+		return err
+	}
+	return checkPoint(t.Pos)
+}
+
+// Sides returns the lengths of the sides opposite each vertex.
+func (t Triangle) Sides() (a, b, c float64) {
+	v := t.Vertices
+	return v[1].Dist(v[2]), v[2].Dist(v[0]), v[0].Dist(v[1])
+}
+
+// Area returns the triangle area.
+func (t Triangle) Area() float64 { return polygonArea(t.Vertices[:]) }
+
+// Perimeter returns the triangle perimeter.
+func (t Triangle) Perimeter() float64 {
+	a, b, c := t.Sides()
+	return a + b + c
+}
+
+// ----------------------
+// 2. Regular polygon
+// ----------------------
+
+// RegularPolygon has N equal sides of length Side.
+type RegularPolygon struct {
+	N    int     `json:"n"`
+	Side float64 `json:"side"`
+	Placement
+}
+
+// maxRegularSides bounds N, since Vertices allocates one point per side.
+const maxRegularSides = 1 << 16
+
+// NewRegularPolygon returns a regular polygon with n sides of the given length.
+func NewRegularPolygon(n int, side float64) (RegularPolygon, error) {
+	if n < 3 || n > maxRegularSides {
+		return RegularPolygon{}, fmt.Errorf("%w: a regular polygon needs 3 to %d sides, got %d", ErrInvalidShape, maxRegularSides, n)
+	}
+	if err := checkLength("side", side); err != nil {
+		return RegularPolygon{}, err
+	}
+	return RegularPolygon{N: n, Side: side}, nil
+}
+
+func (p RegularPolygon) validate() error {
+	if _, err := NewRegularPolygon(p.N, p.Side); err != nil {
+		return err
+	}
+	return checkPoint(p.Pos)
+}
+
+// Circumradius returns the distance from the center to each vertex.
+func (p RegularPolygon) Circumradius() float64 {
+	return p.Side / (2 * math.Sin(math.Pi/float64(p.N)))
+}
+
+// Vertices returns the corners around the origin, starting at the top and
+// going counter-clockwise. It returns nil if N is less than 3.
+func (p RegularPolygon) Vertices() []Point {
+	if p.N < 3 {
+		return nil
+	}
+	r := p.Circumradius()
+	out := make([]Point, p.N)
+	for i := range out {
+		angle := math.Pi/2 + 2*math.Pi*float64(i)/float64(p.N)
+		out[i] = Point{r * math.Cos(angle), r * math.Sin(angle)}
+	}
+	return out
+}
+
+// Area returns the regular polygon area.
+func (p RegularPolygon) Area() float64 {
+	n := float64(p.N)
+	return n * p.Side * p.Side / (4 * math.Tan(math.Pi/n))
+}
+
+// Perimeter returns the regular polygon perimeter.
+func (p RegularPolygon) Perimeter() float64 { return float64(p.N) * p.Side }
+
+// ----------------------
+// 3. Arbitrary polygon
+// ----------------------
+
+// Polygon is a simple (non-self-intersecting) polygon. The closing edge
+// from the last vertex back to the first is implicit.
+type Polygon struct {
+	Vertices []Point `json:"vertices"`
+	Placement
+}
+
+// NewPolygon returns the polygon through vertices, in order. It rejects
+// fewer than three vertices, repeated consecutive vertices, zero area and
+// edges that cross each other. vertices is copied.
+func NewPolygon(vertices ...Point) (Polygon, error) {
+	n := len(vertices)
+	if n < 3 {
+		return Polygon{}, fmt.Errorf("%w: a polygon needs at least 3 vertices, got %d", ErrInvalidShape, n)
+	}
+	for i, v := range vertices {
+		if err := checkPoint(v); err != nil {
+			return Polygon{}, err
+		}
+		if v == vertices[(i+1)%n] {
+			return Polygon{}, fmt.Errorf("%w: vertex %d repeats %v", ErrInvalidShape, i, v)
+		}
+	}
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue // adjacent edges share a vertex
+			}
+			if segmentsIntersect(vertices[i], vertices[(i+1)%n], vertices[j], vertices[(j+1)%n]) {
+				return Polygon{}, fmt.Errorf("%w: edges %d and %d cross", ErrInvalidShape, i, j)
+			}
+		}
+	}
+	if polygonArea(vertices) == 0 {
+		return Polygon{}, fmt.Errorf("%w: polygon has zero area", ErrInvalidShape)
+	}
+	return Polygon{Vertices: append([]Point(nil), vertices...)}, nil
+}
+
+func (p Polygon) validate() error {
+	if _, err := NewPolygon(p.Vertices...); err != nil {
+		return err
+	}
+	return checkPoint(p.Pos)
+}
+
+// Area uses the shoelace formula.
+func (p Polygon) Area() float64 { return polygonArea(p.Vertices) }
+
+// Perimeter returns the polygon perimeter.
+func (p Polygon) Perimeter() float64 {
+	sum := 0.0
+	for i, v := range p.Vertices {
+		sum += v.Dist(p.Vertices[(i+1)%len(p.Vertices)])
+	}
+	return sum
+}
+
+// polygonArea is the shoelace formula: half the absolute sum of the cross
+// products of consecutive vertices.
+func polygonArea(vs []Point) float64 {
+	sum := 0.0
+	for i, v := range vs {
+		w := vs[(i+1)%len(vs)]
+		sum += v.X*w.Y - w.X*v.Y
+	}
+	return math.Abs(sum) / 2
+}
+
+// segmentsIntersect reports whether segments ab and cd share any point.
+func segmentsIntersect(a, b, c, d Point) bool {
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(c, d, a)) || (d2 == 0 && onSegment(c, d, b)) ||
+		(d3 == 0 && onSegment(a, b, c)) || (d4 == 0 && onSegment(a, b, d))
+}
+
+// onSegment reports whether p, known to be collinear with ab, lies on it.
+func onSegment(a, b, p Point) bool {
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+		min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y)
+}
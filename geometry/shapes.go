@@ -0,0 +1,131 @@
+package geometry
+
+import "math"
+
+// ----------------------
+// 1. Rectangle and Square
+// ----------------------
+
+// Rectangle is an axis-aligned rectangle.
+type Rectangle struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Placement
+}
+
+// NewRectangle returns a width x height rectangle.
+func NewRectangle(width, height float64) (Rectangle, error) {
+	if err := checkLength("width", width); err != nil {
+		return Rectangle{}, err
+	}
+	if err := checkLength("height", height); err != nil {
+		return Rectangle{}, err
+	}
+	return Rectangle{Width: width, Height: height}, nil
+}
+
+func (r Rectangle) validate() error {
+	if _, err := NewRectangle(r.Width, r.Height); err != nil {
+		return err
+	}
+	return checkPoint(r.Pos)
+}
+
+// Area returns the rectangle area.
+func (r Rectangle) Area() float64 { return r.Width * r.Height }
+
+// Perimeter returns the rectangle perimeter.
+func (r Rectangle) Perimeter() float64 { return 2 * (r.Width + r.Height) }
+
+// Square is a rectangle with equal sides.
+type Square struct {
+	Side float64 `json:"side"`
+	Placement
+}
+
+// NewSquare returns a square with the given side.
+func NewSquare(side float64) (Square, error) {
+	if err := checkLength("side", side); err != nil {
+		return Square{}, err
+	}
+	return Square{Side: side}, nil
+}
+
+func (s Square) validate() error {
+	if _, err := NewSquare(s.Side); err != nil {
+		return err
+	}
+	return checkPoint(s.Pos)
+}
+
+// Area returns the square area.
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+// Perimeter returns the square perimeter.
+func (s Square) Perimeter() float64 { return 4 * s.Side }
+
+// ----------------------
+// 2. Circle and Ellipse
+// ----------------------
+
+// Circle is a circle of the given radius.
+type Circle struct {
+	Radius float64 `json:"radius"`
+	Placement
+}
+
+// NewCircle returns a circle with the given radius.
+func NewCircle(radius float64) (Circle, error) {
+	if err := checkLength("radius", radius); err != nil {
+		return Circle{}, err
+	}
+	return Circle{Radius: radius}, nil
+}
+
+func (c Circle) validate() error {
+	if _, err := NewCircle(c.Radius); err != nil {
+		return err
+	}
+	return checkPoint(c.Pos)
+}
+
+// Area returns the circle area.
+func (c Circle) Area() float64 { return math.Pi * c.Radius * c.Radius }
+
+// Perimeter returns the circle perimeter.
+func (c Circle) Perimeter() float64 { return 2 * math.Pi * c.Radius }
+
+// Ellipse has horizontal semi-axis RX and vertical semi-axis RY.
+type Ellipse struct {
+	RX float64 `json:"rx"`
+	RY float64 `json:"ry"`
+	Placement
+}
+
+// NewEllipse returns an ellipse with semi-axes rx and ry.
+func NewEllipse(rx, ry float64) (Ellipse, error) {
+	if err := checkLength("rx", rx); err != nil {
+		return Ellipse{}, err
+	}
+	if err := checkLength("ry", ry); err != nil {
+		return Ellipse{}, err
+	}
+	return Ellipse{RX: rx, RY: ry}, nil
+}
+
+func (e Ellipse) validate() error {
+	if _, err := NewEllipse(e.RX, e.RY); err != nil {
+		return err
+	}
+	return checkPoint(e.Pos)
+}
+
+// Area returns the ellipse area.
+func (e Ellipse) Area() float64 { return math.Pi * e.RX * e.RY }
+
+// Perimeter has no closed form; this is Ramanujan's approximation, exact
+// for circles and within 0.5% even for very flat ellipses.
+func (e Ellipse) Perimeter() float64 {
+	a, b := e.RX, e.RY
+	return math.Pi * (3*(a+b) - math.Sqrt((3*a+b)*(a+3*b)))
+}
@@ -0,0 +1,108 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAreaPerimeter(t *testing.T) {
+	tri, _ := NewTriangle(3, 4, 5)
+	tests := []struct {
+		name            string
+		s               Shape
+		area, perimeter float64
+	}{
+		{"rectangle", Rectangle{Width: 5, Height: 4}, 20, 18},
+		{"square", Square{Side: 3}, 9, 12},
+		{"circle", Circle{Radius: 2}, 4 * math.Pi, 4 * math.Pi},
+		{"ellipse as circle", Ellipse{RX: 2, RY: 2}, 4 * math.Pi, 4 * math.Pi},
+		{"ellipse", Ellipse{RX: 3, RY: 1}, 3 * math.Pi, math.Pi * (12 - math.Sqrt(60))}, // Ramanujan; exact is 13.36489...
+		{"right triangle", tri, 6, 12},
+		{"triangle from points", triangle(Point{0, 0}, Point{4, 0}, Point{1, 3}), 6, 4 + math.Sqrt(10) + math.Sqrt(18)},
+		{"hexagon", RegularPolygon{N: 6, Side: 2}, 6 * math.Sqrt(3), 12},
+		{"square as regular polygon", RegularPolygon{N: 4, Side: 3}, 9, 12},
+		{"u shape", uShape, 7, 16},
+		{"clockwise polygon", Polygon{Vertices: []Point{{0, 0}, {0, 2}, {2, 2}, {2, 0}}}, 4, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Area(); math.Abs(got-tt.area) > eps {
+				t.Errorf("Area() = %g, want %g", got, tt.area)
+			}
+			if got := tt.s.Perimeter(); math.Abs(got-tt.perimeter) > eps {
+				t.Errorf("Perimeter() = %g, want %g", got, tt.perimeter)
+			}
+		})
+	}
+}
+
+func TestRegularPolygonVertices(t *testing.T) {
+	for _, n := range []int{3, 4, 5, 6, 12} {
+		p := RegularPolygon{N: n, Side: 1.5}
+		vs := p.Vertices()
+		if len(vs) != n {
+			t.Fatalf("N=%d: %d vertices", n, len(vs))
+		}
+		if vs[0].X > eps || math.Abs(vs[0].Y-p.Circumradius()) > eps {
+			t.Errorf("N=%d: first vertex %v is not at the top", n, vs[0])
+		}
+		poly := Polygon{Vertices: vs}
+		if math.Abs(poly.Area()-p.Area()) > eps || math.Abs(poly.Perimeter()-p.Perimeter()) > eps {
+			t.Errorf("N=%d: vertices give area %g and perimeter %g, want %g and %g",
+				n, poly.Area(), poly.Perimeter(), p.Area(), p.Perimeter())
+		}
+	}
+}
+
+func TestConstructors(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		name string
+		err  error
+		ok   bool
+	}{
+		{"rectangle", second(NewRectangle(2, 3)), true},
+		{"rectangle zero width", second(NewRectangle(0, 3)), false},
+		{"rectangle NaN height", second(NewRectangle(2, nan)), false},
+		{"square", second(NewSquare(1)), true},
+		{"square negative", second(NewSquare(-1)), false},
+		{"circle", second(NewCircle(0.5)), true},
+		{"circle infinite", second(NewCircle(inf)), false},
+		{"ellipse", second(NewEllipse(2, 1)), true},
+		{"ellipse zero ry", second(NewEllipse(2, 0)), false},
+		{"triangle", second(NewTriangle(3, 4, 5)), true},
+		{"triangle inequality", second(NewTriangle(1, 2, 3)), false},
+		{"triangle zero side", second(NewTriangle(0, 4, 5)), false},
+		{"triangle from points", second(TriangleFromPoints(Point{0, 0}, Point{1, 0}, Point{0, 1})), true},
+		{"triangle collinear", second(TriangleFromPoints(Point{0, 0}, Point{1, 1}, Point{3, 3})), false},
+		{"triangle NaN point", second(TriangleFromPoints(Point{nan, 0}, Point{1, 0}, Point{0, 1})), false},
+		{"regular polygon", second(NewRegularPolygon(5, 1)), true},
+		{"regular polygon two sides", second(NewRegularPolygon(2, 1)), false},
+		{"regular polygon too many sides", second(NewRegularPolygon(maxRegularSides+1, 1)), false},
+		{"regular polygon zero side", second(NewRegularPolygon(5, 0)), false},
+		{"polygon", second(NewPolygon(uShape.Vertices...)), true},
+		{"polygon two vertices", second(NewPolygon(Point{0, 0}, Point{1, 0})), false},
+		{"polygon repeated vertex", second(NewPolygon(Point{0, 0}, Point{0, 0}, Point{1, 0}, Point{0, 1})), false},
+		{"polygon bowtie", second(NewPolygon(Point{0, 0}, Point{2, 2}, Point{2, 0}, Point{0, 2})), false},
+		{"polygon flat", second(NewPolygon(Point{0, 0}, Point{1, 0}, Point{2, 0})), false},
+	}
+	for _, tt := range tests {
+		if tt.ok && tt.err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, tt.err)
+		}
+		if !tt.ok && !errors.Is(tt.err, ErrInvalidShape) {
+			t.Errorf("%s: err = %v, want ErrInvalidShape", tt.name, tt.err)
+		}
+	}
+
+	// NewPolygon copies its vertices.
+	vs := []Point{{0, 0}, {1, 0}, {0, 1}}
+	p, _ := NewPolygon(vs...)
+	vs[0] = Point{5, 5}
+	if p.Vertices[0] != (Point{0, 0}) {
+		t.Error("NewPolygon kept a reference to its argument")
+	}
+}
+
+func second[S any](_ S, err error) error { return err }
@@ -0,0 +1,192 @@
+package geometry
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVGOptions controls WriteSVG.
+type SVGOptions struct {
+	// Margin is the space left around the drawing, in drawing units.
+	Margin float64
+
+	// Scale sets the document's width and height to Scale pixels per
+	// drawing unit. Zero leaves them out, so the image fills its container.
+	Scale float64
+
+	// LabelArea prints each shape's area at its centre.
+	LabelArea bool
+
+	// Style is used for any field a shape's own Style leaves empty.
+	// Its own empty fields default to no fill and a black 1-unit outline.
+	Style Style
+}
+
+// svgElement is one rendered shape: its markup, extent and label anchor.
+type svgElement struct {
+	markup string
+	box    AABB
+	center Point
+	area   float64
+}
+
+// WriteSVG writes shapes as a standalone SVG document, in order, so later
+// shapes are drawn on top. The viewBox is fitted around all of them.
+//
+// Geometry uses y pointing up while SVG uses y pointing down; WriteSVG
+// flips the drawing so it looks the way the coordinates read. It returns
+// an error for shape types it does not know how to draw.
+func WriteSVG(w io.Writer, shapes []Shape, opts SVGOptions) error {
+	elems := make([]svgElement, 0, len(shapes))
+	for i, s := range shapes {
+		e, err := svgFor(s, opts.Style)
+		if err != nil {
+			return fmt.Errorf("geometry: shape %d: %w", i, err)
+		}
+		elems = append(elems, e)
+	}
+
+	var box AABB
+	for i, e := range elems {
+		if i == 0 {
+			box = e.box
+		} else {
+			box = box.Union(e.box)
+		}
+	}
+	box = box.Expand(max(opts.Margin, 0))
+	x, y := box.Min.X, -box.Max.Y // top-left corner after flipping y
+	width, height := box.Width(), box.Height()
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%s %s %s %s"`, num(x), num(y), num(width), num(height))
+	if opts.Scale > 0 {
+		fmt.Fprintf(bw, ` width="%s" height="%s"`, num(width*opts.Scale), num(height*opts.Scale))
+	}
+	bw.WriteString(">\n")
+	for _, e := range elems {
+		bw.WriteString("  " + e.markup + "\n")
+	}
+	if opts.LabelArea {
+		size := max(width, height) / 40
+		for _, e := range elems {
+			fmt.Fprintf(bw, `  <text x="%s" y="%s" font-size="%s" font-family="sans-serif" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n",
+				num(e.center.X), num(-e.center.Y), num(size), strconv.FormatFloat(e.area, 'f', 2, 64))
+		}
+	}
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+// svgFor renders one shape, with y flipped in the markup but not in the
+// returned extent.
+func svgFor(s Shape, fallback Style) (svgElement, error) {
+	var (
+		p     Placement
+		b     AABB
+		attrs string
+	)
+	switch s := s.(type) {
+	case Rectangle:
+		p, b = s.Placement, s.Bounds()
+		attrs = svgRect(b)
+	case Square:
+		p, b = s.Placement, s.Bounds()
+		attrs = svgRect(b)
+	case Circle:
+		p, b = s.Placement, s.Bounds()
+		attrs = fmt.Sprintf(`<circle cx="%s" cy="%s" r="%s"`, num(p.Pos.X), num(-p.Pos.Y), num(s.Radius))
+	case Ellipse:
+		p, b = s.Placement, s.Bounds()
+		attrs = fmt.Sprintf(`<ellipse cx="%s" cy="%s" rx="%s" ry="%s"`, num(p.Pos.X), num(-p.Pos.Y), num(s.RX), num(s.RY))
+	case Triangle:
+		p, b = s.Placement, s.Bounds()
+		attrs = svgPolygon(s.Outline())
+	case RegularPolygon:
+		p, b = s.Placement, s.Bounds()
+		attrs = svgPolygon(s.Outline())
+	case Polygon:
+		p, b = s.Placement, s.Bounds()
+		attrs = svgPolygon(s.Outline())
+	default:
+		return svgElement{}, fmt.Errorf("cannot render %T", s)
+	}
+
+	// Labels go at the centre of mass, which for the symmetric shapes is Pos.
+	center := p.Pos
+	switch s := s.(type) {
+	case Triangle:
+		center = centroid(s.Outline())
+	case Polygon:
+		center = centroid(s.Outline())
+	}
+
+	st := p.Style.or(fallback).or(Style{Fill: "none", Stroke: "black", StrokeWidth: 1})
+	return svgElement{
+		markup: fmt.Sprintf(`%s fill="%s" stroke="%s" stroke-width="%s"/>`,
+			attrs, html.EscapeString(st.Fill), html.EscapeString(st.Stroke), num(st.StrokeWidth)),
+		box:    b.Expand(st.StrokeWidth / 2), // the outline straddles the edge
+		center: center,
+		area:   s.Area(),
+	}, nil
+}
+
+// svgRect returns the opening tag of a rect covering b.
+func svgRect(b AABB) string {
+	return fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s"`,
+		num(b.Min.X), num(-b.Max.Y), num(b.Width()), num(b.Height()))
+}
+
+// svgPolygon returns the opening tag of a polygon through pts.
+func svgPolygon(pts []Point) string {
+	coords := make([]string, len(pts))
+	for i, v := range pts {
+		coords[i] = num(v.X) + "," + num(-v.Y)
+	}
+	return `<polygon points="` + strings.Join(coords, " ") + `"`
+}
+
+// centroid returns the centre of mass of a simple polygon.
+func centroid(vs []Point) Point {
+	var a, cx, cy float64
+	for i, v := range vs {
+		w := vs[(i+1)%len(vs)]
+		c := v.X*w.Y - w.X*v.Y
+		a += c
+		cx += (v.X + w.X) * c
+		cy += (v.Y + w.Y) * c
+	}
+	if a == 0 {
+		return Point{}
+	}
+	return Point{cx / (3 * a), cy / (3 * a)}
+}
+
+// or fills the empty fields of s from d.
+func (s Style) or(d Style) Style {
+	if s.Fill == "" {
+		s.Fill = d.Fill
+	}
+	if s.Stroke == "" {
+		s.Stroke = d.Stroke
+	}
+	if s.StrokeWidth == 0 {
+		s.StrokeWidth = d.StrokeWidth
+	}
+	return s
+}
+
+// num formats a coordinate with at most three decimals.
+func num(v float64) string {
+	v = math.Round(v*1000) / 1000
+	if v == 0 {
+		v = 0 // avoid "-0"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package geometry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	r := rectAt(2, 1, 4, 2)
+	c := circleAt(6, 1, 1)
+	c.Style = Style{Fill: "#a6cee3", Stroke: "#1f78b4", StrokeWidth: 0.2}
+	tri := triangle(Point{0, 0}, Point{2, 0}, Point{0, 3})
+	tri.Pos = Point{8, 0}
+	e := ellipseAt(-2, -1, 1, 0.5)
+	e.Style.Fill = `a"b<c` // escaped in the attribute
+	opts := SVGOptions{Margin: 1, Scale: 10, LabelArea: true}
+	opts.Style.StrokeWidth = 0.1
+
+	// The viewBox holds every shape plus half its stroke and the margin,
+	// with y flipped; the triangle's label sits at its centroid.
+	const want = `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="-4.05 -4.05 15.1 6.6" width="151" height="66">
+  <rect x="0" y="-2" width="4" height="2" fill="none" stroke="black" stroke-width="0.1"/>
+  <circle cx="6" cy="-1" r="1" fill="#a6cee3" stroke="#1f78b4" stroke-width="0.2"/>
+  <polygon points="8,0 10,0 8,-3" fill="none" stroke="black" stroke-width="0.1"/>
+  <ellipse cx="-2" cy="1" rx="1" ry="0.5" fill="a&#34;b&lt;c" stroke="black" stroke-width="0.1"/>
+  <text x="2" y="-1" font-size="0.378" font-family="sans-serif" text-anchor="middle" dominant-baseline="middle">8.00</text>
+  <text x="6" y="-1" font-size="0.378" font-family="sans-serif" text-anchor="middle" dominant-baseline="middle">3.14</text>
+  <text x="8.667" y="-1" font-size="0.378" font-family="sans-serif" text-anchor="middle" dominant-baseline="middle">3.00</text>
+  <text x="-2" y="1" font-size="0.378" font-family="sans-serif" text-anchor="middle" dominant-baseline="middle">1.57</text>
+</svg>
+`
+	var b strings.Builder
+	if err := WriteSVG(&b, []Shape{r, c, tri, e}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("WriteSVG wrote\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteSVGDefaults(t *testing.T) {
+	sq := squareAt(0, 0, 2)
+	hex := hexAt(3, 0, 1)
+	const want = `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="-1.5 -1.5 5.866 3">
+  <rect x="-1" y="-1" width="2" height="2" fill="none" stroke="black" stroke-width="1"/>
+  <polygon points="3,-1 2.134,-0.5 2.134,0.5 3,1 3.866,0.5 3.866,-0.5" fill="none" stroke="black" stroke-width="1"/>
+</svg>
+`
+	var b strings.Builder
+	if err := WriteSVG(&b, []Shape{sq, hex}, SVGOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("WriteSVG wrote\n%s\nwant\n%s", got, want)
+	}
+}
+
+type blob struct{}
+
+func (blob) Area() float64      { return 1 }
+func (blob) Perimeter() float64 { return 4 }
+
+func TestWriteSVGUnknownShape(t *testing.T) {
+	var b strings.Builder
+	err := WriteSVG(&b, []Shape{circleAt(0, 0, 1), blob{}}, SVGOptions{})
+	if err == nil || !strings.Contains(err.Error(), "shape 1") {
+		t.Fatalf("err = %v, want an error naming shape 1", err)
+	}
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes the graph in Graphviz DOT format, for example to render
+// with `dot -Tsvg`. Node names are quoted with fmt.Sprint of the key, and
+// every edge is labelled with its weight.
+func (g *Graph[K]) WriteDOT(w io.Writer, name string) error {
+	bw := bufio.NewWriter(w)
+	kind, arrow := "graph", "--"
+	if g.directed {
+		kind, arrow = "digraph", "->"
+	}
+	fmt.Fprintf(bw, "%s %s {\n", kind, strconv.Quote(name))
+	for _, k := range g.Nodes() {
+		fmt.Fprintf(bw, "  %s;\n", quote(k))
+	}
+	for _, e := range g.AllEdges() {
+		fmt.Fprintf(bw, "  %s %s %s [label=%s];\n",
+			quote(e.From), arrow, quote(e.To), strconv.Quote(strconv.FormatFloat(e.Weight, 'g', -1, 64)))
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// quote renders a node key as a DOT string ID.
+func quote(k any) string {
+	return strconv.Quote(fmt.Sprint(k))
+}
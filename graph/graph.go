@@ -0,0 +1,118 @@
+// Package graph provides a generic adjacency-list graph with weighted edges
+// and the classic algorithms: BFS/DFS, Dijkstra, A*, topological sort,
+// strongly connected components and minimum spanning trees.
+//
+// Nodes and each node's edges are kept in insertion order, so every
+// algorithm is deterministic: the same graph always gives the same answer.
+package graph
+
+import (
+	"errors"
+	"orderedmap"
+)
+
+var (
+	// ErrNoPath is returned when the target cannot be reached.
+	ErrNoPath = errors.New("graph: no path")
+	// ErrNegativeWeight is returned by Dijkstra and A*, which require weights >= 0.
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrUndirected is returned by algorithms that only make sense on directed graphs.
+	ErrUndirected = errors.New("graph: operation requires a directed graph")
+	// ErrDirected is returned by algorithms that only make sense on undirected graphs.
+	ErrDirected = errors.New("graph: operation requires an undirected graph")
+)
+
+// Edge is a weighted connection from From to To.
+type Edge[K comparable] struct {
+	From, To K
+	Weight   float64
+}
+
+// Graph is a directed or undirected graph over node keys of type K.
+type Graph[K comparable] struct {
+	directed bool
+	adj      *orderedmap.OrderedMap[K, []Edge[K]]
+}
+
+// NewDirected returns an empty directed graph.
+func NewDirected[K comparable]() *Graph[K] {
+	return &Graph[K]{directed: true, adj: orderedmap.New[K, []Edge[K]]()}
+}
+
+// NewUndirected returns an empty undirected graph.
+func NewUndirected[K comparable]() *Graph[K] {
+	return &Graph[K]{adj: orderedmap.New[K, []Edge[K]]()}
+}
+
+// Directed reports whether edges have a direction.
+func (g *Graph[K]) Directed() bool { return g.directed }
+
+// AddNode adds k if it is not already present.
+func (g *Graph[K]) AddNode(k K) {
+	if !g.adj.Has(k) {
+		g.adj.Set(k, nil)
+	}
+}
+
+// AddEdge connects from to to with the given weight, adding missing nodes.
+// In an undirected graph the edge is usable in both directions.
+func (g *Graph[K]) AddEdge(from, to K, weight float64) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.appendEdge(Edge[K]{from, to, weight})
+	if !g.directed && from != to {
+		g.appendEdge(Edge[K]{to, from, weight})
+	}
+}
+
+func (g *Graph[K]) appendEdge(e Edge[K]) {
+	edges, _ := g.adj.Get(e.From)
+	g.adj.Set(e.From, append(edges, e))
+}
+
+// HasNode reports whether k is in the graph.
+func (g *Graph[K]) HasNode(k K) bool { return g.adj.Has(k) }
+
+// HasEdge reports whether there is an edge from from to to.
+func (g *Graph[K]) HasEdge(from, to K) bool {
+	for _, e := range g.Edges(from) {
+		if e.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Nodes returns every node in insertion order.
+func (g *Graph[K]) Nodes() []K { return g.adj.Keys() }
+
+// Len returns the number of nodes.
+func (g *Graph[K]) Len() int { return g.adj.Len() }
+
+// Edges returns the edges leaving k. The slice must not be modified.
+func (g *Graph[K]) Edges(k K) []Edge[K] {
+	edges, _ := g.adj.Get(k)
+	return edges
+}
+
+// AllEdges returns every edge once; each undirected edge is reported in
+// only one of its two directions.
+func (g *Graph[K]) AllEdges() []Edge[K] {
+	var out []Edge[K]
+	seen := make(map[[2]K]int) // undirected: count of reverse edges still to skip
+	for _, edges := range g.adj.All() {
+		for _, e := range edges {
+			if !g.directed {
+				if seen[[2]K{e.From, e.To}] > 0 {
+					seen[[2]K{e.From, e.To}]--
+					continue
+				}
+				if e.From != e.To {
+					seen[[2]K{e.To, e.From}]++
+				}
+			}
+			out = append(out, e)
+		}
+	}
+	return out
+}
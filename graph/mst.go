@@ -0,0 +1,42 @@
+package graph
+
+import "pqueue"
+
+// MinimumSpanningTree returns a minimum spanning forest of an undirected
+// graph (one tree per connected component) and its total weight.
+// It uses Prim's algorithm, growing each tree from its first inserted node.
+func (g *Graph[K]) MinimumSpanningTree() (*Graph[K], float64, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+	tree := NewUndirected[K]()
+	inTree := make(map[K]bool, g.Len())
+	total := 0.0
+
+	for _, root := range g.Nodes() {
+		if inTree[root] {
+			continue
+		}
+		tree.AddNode(root)
+		inTree[root] = true
+		pq := pqueue.New(func(a, b Edge[K]) bool { return a.Weight < b.Weight })
+		for _, e := range g.Edges(root) {
+			pq.Push(e)
+		}
+		for pq.Len() > 0 {
+			e, _ := pq.Pop()
+			if inTree[e.To] {
+				continue
+			}
+			inTree[e.To] = true
+			tree.AddEdge(e.From, e.To, e.Weight)
+			total += e.Weight
+			for _, next := range g.Edges(e.To) {
+				if !inTree[next.To] {
+					pq.Push(next)
+				}
+			}
+		}
+	}
+	return tree, total, nil
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ----------------------
+// 1. Topological sort
+// ----------------------
+
+// CycleError is returned by TopoSort when the graph has a cycle.
+type CycleError[K comparable] struct {
+	Cycle []K // the nodes on one cycle, first node repeated at the end
+}
+
+func (e *CycleError[K]) Error() string {
+	parts := make([]string, len(e.Cycle))
+	for i, k := range e.Cycle {
+		parts[i] = fmt.Sprint(k)
+	}
+	return "graph: cycle " + strings.Join(parts, " -> ")
+}
+
+// TopoSort orders the nodes so that every edge points from an earlier node to
+// a later one, e.g. dependencies before the things that depend on them when
+// edges run dependency -> dependent. Ties keep insertion order.
+// If the graph has a cycle it returns a *CycleError naming one.
+func (g *Graph[K]) TopoSort() ([]K, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	const (
+		unvisited = iota
+		inProgress
+		finished
+	)
+	state := make(map[K]int, g.Len())
+	var order []K
+	var stack []K // current DFS path, for reporting cycles
+
+	var visit func(k K) error
+	visit = func(k K) error {
+		state[k] = inProgress
+		stack = append(stack, k)
+		for _, e := range g.Edges(k) {
+			switch state[e.To] {
+			case inProgress:
+				start := slices.Index(stack, e.To)
+				cycle := append(slices.Clone(stack[start:]), e.To)
+				return &CycleError[K]{Cycle: cycle}
+			case unvisited:
+				if err := visit(e.To); err != nil {
+					return err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[k] = finished
+		order = append(order, k)
+		return nil
+	}
+
+	nodes := g.Nodes()
+	// Visit in reverse so that, after the final reversal, unrelated nodes
+	// come out in insertion order.
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if state[nodes[i]] == unvisited {
+			if err := visit(nodes[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	slices.Reverse(order)
+	return order, nil
+}
+
+// ----------------------
+// 2. Strongly connected components (Tarjan)
+// ----------------------
+
+// StronglyConnected returns the strongly connected components of a directed
+// graph: maximal groups in which every node can reach every other.
+// Components come out in reverse topological order of the condensed graph,
+// so a component appears before any component that has an edge into it.
+func (g *Graph[K]) StronglyConnected() ([][]K, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	var (
+		index   = make(map[K]int)
+		lowlink = make(map[K]int)
+		onStack = make(map[K]bool)
+		stack   []K
+		next    int
+		comps   [][]K
+	)
+
+	var connect func(k K)
+	connect = func(k K) {
+		index[k], lowlink[k] = next, next
+		next++
+		stack = append(stack, k)
+		onStack[k] = true
+
+		for _, e := range g.Edges(k) {
+			if _, seen := index[e.To]; !seen {
+				connect(e.To)
+				lowlink[k] = min(lowlink[k], lowlink[e.To])
+			} else if onStack[e.To] {
+				lowlink[k] = min(lowlink[k], index[e.To])
+			}
+		}
+
+		if lowlink[k] == index[k] { // k is the root of a component
+			var comp []K
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				comp = append(comp, top)
+				if top == k {
+					break
+				}
+			}
+			slices.Reverse(comp)
+			comps = append(comps, comp)
+		}
+	}
+
+	for _, k := range g.Nodes() {
+		if _, seen := index[k]; !seen {
+			connect(k)
+		}
+	}
+	return comps, nil
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"pqueue"
+	"slices"
+)
+
+// ----------------------
+// 1. Dijkstra
+// ----------------------
+
+// Dijkstra computes the shortest distance from src to every reachable node.
+// prev maps each reached node (except src) to its predecessor on a shortest
+// path; use PathTo to turn it into a path.
+func (g *Graph[K]) Dijkstra(src K) (dist map[K]float64, prev map[K]K, err error) {
+	dist, prev, _, err = g.search(src, nil, nil)
+	return dist, prev, err
+}
+
+// ShortestPath returns the cheapest path from src to dst and its total weight.
+func (g *Graph[K]) ShortestPath(src, dst K) ([]K, float64, error) {
+	return g.AStar(src, dst, nil)
+}
+
+// ----------------------
+// 2. A*
+// ----------------------
+
+// AStar finds the cheapest path from src to dst, guided by heuristic, an
+// estimate of the remaining cost to dst. The result is optimal when the
+// heuristic is consistent (never overestimates, and never drops by more than
+// an edge's weight along that edge), as straight-line distance is on a map.
+// A nil heuristic makes this plain Dijkstra.
+func (g *Graph[K]) AStar(src, dst K, heuristic func(K) float64) ([]K, float64, error) {
+	dist, prev, found, err := g.search(src, &dst, heuristic)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !found {
+		return nil, 0, ErrNoPath
+	}
+	return PathTo(prev, src, dst), dist[dst], nil
+}
+
+// PathTo rebuilds the path from src to dst out of a predecessor map.
+// It returns nil if dst was not reached.
+func PathTo[K comparable](prev map[K]K, src, dst K) []K {
+	path := []K{dst}
+	for k := dst; k != src; {
+		p, ok := prev[k]
+		if !ok {
+			return nil
+		}
+		path = append(path, p)
+		k = p
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// ----------------------
+// 3. Shared search
+// ----------------------
+
+type frontier[K comparable] struct {
+	node     K
+	priority float64 // distance so far plus heuristic estimate
+}
+
+// search runs Dijkstra (dst == nil) or A* towards *dst. It uses the priority
+// queue's Update for decrease-key, so every node is queued at most once.
+func (g *Graph[K]) search(src K, dst *K, heuristic func(K) float64) (map[K]float64, map[K]K, bool, error) {
+	if !g.HasNode(src) {
+		return nil, nil, false, ErrNoPath
+	}
+	if heuristic == nil {
+		heuristic = func(K) float64 { return 0 }
+	}
+	dist := map[K]float64{src: 0}
+	prev := make(map[K]K)
+	done := make(map[K]bool)
+	handles := make(map[K]*pqueue.Handle[frontier[K]])
+
+	pq := pqueue.New(func(a, b frontier[K]) bool { return a.priority < b.priority })
+	handles[src] = pq.Push(frontier[K]{src, heuristic(src)})
+	for pq.Len() > 0 {
+		cur, _ := pq.Pop()
+		k := cur.node
+		done[k] = true
+		if dst != nil && k == *dst {
+			return dist, prev, true, nil
+		}
+		for _, e := range g.Edges(k) {
+			if e.Weight < 0 {
+				return nil, nil, false, ErrNegativeWeight
+			}
+			if done[e.To] {
+				continue
+			}
+			d := dist[k] + e.Weight
+			old, seen := dist[e.To]
+			if seen && d >= old {
+				continue
+			}
+			dist[e.To], prev[e.To] = d, k
+			next := frontier[K]{e.To, d + heuristic(e.To)}
+			if h, ok := handles[e.To]; ok && h.Queued() {
+				pq.Update(h, next)
+			} else {
+				handles[e.To] = pq.Push(next)
+			}
+		}
+	}
+	return dist, prev, dst == nil, nil
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"iter"
+	"queue"
+)
+
+// BFS visits the nodes reachable from start in breadth-first order.
+// Nodes at the same depth follow edge insertion order.
+func (g *Graph[K]) BFS(start K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if !g.HasNode(start) {
+			return
+		}
+		seen := map[K]bool{start: true}
+		var q queue.Queue[K]
+		q.Enqueue(start)
+		for q.Len() > 0 {
+			k, _ := q.Dequeue()
+			if !yield(k) {
+				return
+			}
+			for _, e := range g.Edges(k) {
+				if !seen[e.To] {
+					seen[e.To] = true
+					q.Enqueue(e.To)
+				}
+			}
+		}
+	}
+}
+
+// DFS visits the nodes reachable from start in depth-first preorder,
+// exploring edges in insertion order.
+func (g *Graph[K]) DFS(start K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if !g.HasNode(start) {
+			return
+		}
+		seen := make(map[K]bool)
+		var stack queue.Stack[K]
+		stack.Push(start)
+		for stack.Len() > 0 {
+			k, _ := stack.Pop()
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if !yield(k) {
+				return
+			}
+			edges := g.Edges(k)
+			for i := len(edges) - 1; i >= 0; i-- { // reversed so the first edge is popped first
+				if !seen[edges[i].To] {
+					stack.Push(edges[i].To)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package iterx builds lazy pipelines on range-over-func iterators
+// (iter.Seq and iter.Seq2).
+//
+// Nothing runs until a sink (Collect, Reduce, First) or a for-range loop
+// pulls values, and only as many values as needed are produced, so
+// sequences may be huge or even infinite.
+package iterx
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// ----------------------
+// 1. Sources
+// ----------------------
+
+// FromSlice yields the elements of s in order.
+func FromSlice[T any](s []T) iter.Seq[T] {
+	return slices.Values(s)
+}
+
+// FromMap yields the key/value pairs of m in unspecified order.
+func FromMap[K comparable, V any](m map[K]V) iter.Seq2[K, V] {
+	return maps.All(m)
+}
+
+// FromChan yields values received from ch until it is closed.
+// Stopping early leaves the remaining values in the channel.
+func FromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Range yields start, start+step, ... while below end (above end for a
+// negative step). A zero step yields nothing.
+func Range(start, end, step int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		switch {
+		case step > 0:
+			for i := start; i < end; i += step {
+				if !yield(i) {
+					return
+				}
+			}
+		case step < 0:
+			for i := start; i > end; i += step {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ----------------------
+// 2. Adapters
+// ----------------------
+
+// Map yields f(v) for each v in seq.
+func Map[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter yields the values of seq for which keep returns true.
+func Filter[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take yields at most the first n values of seq.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			if i++; i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip yields the values of seq after the first n.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Chain yields every value of each sequence in turn.
+func Chain[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerate pairs each value with its 0-based position, like range over a slice.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Zip pairs the values of a and b; it stops when either runs out.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := next()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// Window yields every run of n consecutive values (a sliding window).
+// Each window is a freshly allocated slice the caller may keep.
+// Panics if n < 1.
+func Window[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("iterx: window size must be positive")
+	}
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, n)
+		for v := range seq {
+			if len(buf) == n {
+				copy(buf, buf[1:])
+				buf = buf[:n-1]
+			}
+			buf = append(buf, v)
+			if len(buf) == n && !yield(slices.Clone(buf)) {
+				return
+			}
+		}
+	}
+}
+
+// ----------------------
+// 3. Sinks
+// ----------------------
+
+// Collect gathers seq into a slice. It never returns for an infinite sequence.
+func Collect[T any](seq iter.Seq[T]) []T {
+	return slices.Collect(seq)
+}
+
+// Reduce folds seq from left to right, starting from init.
+func Reduce[T, A any](seq iter.Seq[T], init A, f func(A, T) A) A {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// First returns the first value of seq, if any.
+func First[T any](seq iter.Seq[T]) (T, bool) {
+	for v := range seq {
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
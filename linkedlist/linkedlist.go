@@ -0,0 +1,301 @@
+// Package linkedlist is a typed doubly linked list.
+//
+// It mirrors container/list but stores T instead of interface{}, so
+// code holding elements (like an LRU cache) never needs type assertions.
+// It also adds splicing of element ranges between lists.
+package linkedlist
+
+import "iter"
+
+// Element is a node of a List. Keep it as a handle for O(1) insert,
+// remove and move operations.
+type Element[T any] struct {
+	Value      T
+	next, prev *Element[T]
+	list       *List[T]
+}
+
+// Next returns the following element, or nil at the back.
+func (e *Element[T]) Next() *Element[T] {
+	if n := e.next; e.list != nil && n != &e.list.root {
+		return n
+	}
+	return nil
+}
+
+// Prev returns the preceding element, or nil at the front.
+func (e *Element[T]) Prev() *Element[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// List is a doubly linked list. The zero value is an empty list ready to use.
+type List[T any] struct {
+	root Element[T] // sentinel: root.next is the front, root.prev the back
+	len  int
+}
+
+// New returns an empty list.
+func New[T any]() *List[T] {
+	return new(List[T]).Init()
+}
+
+// Init empties l and returns it.
+func (l *List[T]) Init() *List[T] {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+	return l
+}
+
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.Init()
+	}
+}
+
+// Len returns the number of elements. O(1).
+func (l *List[T]) Len() int { return l.len }
+
+// Front returns the first element, or nil if l is empty.
+func (l *List[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element, or nil if l is empty.
+func (l *List[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// ----------------------
+// 1. Insertion
+// ----------------------
+
+// PushFront inserts v at the front and returns its element.
+func (l *List[T]) PushFront(v T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: v}, &l.root)
+}
+
+// PushBack inserts v at the back and returns its element.
+func (l *List[T]) PushBack(v T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: v}, l.root.prev)
+}
+
+// InsertBefore inserts v just before mark. It returns nil if mark is not in l.
+func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: v}, mark.prev)
+}
+
+// InsertAfter inserts v just after mark. It returns nil if mark is not in l.
+func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: v}, mark)
+}
+
+// ----------------------
+// 2. Removal and moves
+// ----------------------
+
+// Remove unlinks e from l if it belongs to l and returns its value.
+func (l *List[T]) Remove(e *Element[T]) T {
+	if e.list == l {
+		l.unlink(e)
+		e.next, e.prev, e.list = nil, nil, nil // avoid memory leaks
+		l.len--
+	}
+	return e.Value
+}
+
+// MoveToFront moves e to the front of l. e must belong to l.
+func (l *List[T]) MoveToFront(e *Element[T]) {
+	if e.list != l || l.root.next == e {
+		return
+	}
+	l.move(e, &l.root)
+}
+
+// MoveToBack moves e to the back of l. e must belong to l.
+func (l *List[T]) MoveToBack(e *Element[T]) {
+	if e.list != l || l.root.prev == e {
+		return
+	}
+	l.move(e, l.root.prev)
+}
+
+// MoveBefore moves e just before mark. Both must belong to l.
+func (l *List[T]) MoveBefore(e, mark *Element[T]) {
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark.prev)
+}
+
+// MoveAfter moves e just after mark. Both must belong to l.
+func (l *List[T]) MoveAfter(e, mark *Element[T]) {
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark)
+}
+
+// ----------------------
+// 3. Splicing
+// ----------------------
+
+// SpliceBefore moves the run of elements first..last (inclusive, in list
+// order, both in the list src) so that it sits just before mark in l.
+// src may be l itself, in which case mark must not lie strictly inside the
+// run; splicing a run to where it already is does nothing. A nil first or
+// last, as Front and Back return for an empty src, does nothing either.
+// The elements keep their identity, so existing handles stay valid.
+// Cost is O(k) for a run of k elements, to re-home each element.
+func (l *List[T]) SpliceBefore(mark, first, last *Element[T], src *List[T]) {
+	if first == nil || last == nil || mark.list != l || first.list != src || last.list != src {
+		return
+	}
+	l.spliceAfter(mark.prev, first, last, src)
+}
+
+// SpliceBack moves the run first..last of src to the back of l.
+func (l *List[T]) SpliceBack(first, last *Element[T], src *List[T]) {
+	if first == nil || last == nil || first.list != src || last.list != src {
+		return
+	}
+	l.lazyInit()
+	l.spliceAfter(l.root.prev, first, last, src)
+}
+
+// SpliceFront moves the run first..last of src to the front of l.
+func (l *List[T]) SpliceFront(first, last *Element[T], src *List[T]) {
+	if first == nil || last == nil || first.list != src || last.list != src {
+		return
+	}
+	l.lazyInit()
+	l.spliceAfter(&l.root, first, last, src)
+}
+
+func (l *List[T]) spliceAfter(at, first, last *Element[T], src *List[T]) {
+	if src == l && (at == last || at == first.prev) {
+		return // the run already sits right after at
+	}
+	// Validate and count the run before changing anything.
+	n := 0
+	for e := first; ; e = e.next {
+		if e == &src.root {
+			panic("linkedlist: last does not follow first")
+		}
+		if src == l && e == at {
+			panic("linkedlist: splice target inside the moved range")
+		}
+		n++
+		if e == last {
+			break
+		}
+	}
+	for e := first; e != last.next; e = e.next {
+		e.list = l
+	}
+	// Detach first..last from src.
+	first.prev.next = last.next
+	last.next.prev = first.prev
+	src.len -= n
+	// Attach after at.
+	first.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = first
+	l.len += n
+}
+
+// ----------------------
+// 4. Iteration
+// ----------------------
+
+// All iterates over the values from front to back.
+// Removing the current element during iteration is allowed.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e.Value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward iterates over the values from back to front.
+// Removing the current element during iteration is allowed.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Back(); e != nil; {
+			prev := e.Prev()
+			if !yield(e.Value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// Elements iterates over the element handles from front to back.
+// Removing or moving the current element during iteration is allowed.
+func (l *List[T]) Elements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// ----------------------
+// 5. Internals
+// ----------------------
+
+// insert links e after at and returns e.
+func (l *List[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+	return e
+}
+
+func (l *List[T]) unlink(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+}
+
+// move relinks e after at.
+func (l *List[T]) move(e, at *Element[T]) {
+	if e == at {
+		return
+	}
+	l.unlink(e)
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
@@ -0,0 +1,151 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+)
+
+// build returns a list holding vals and its elements in order.
+func build(vals ...int) (*List[int], []*Element[int]) {
+	l := New[int]()
+	es := make([]*Element[int], len(vals))
+	for i, v := range vals {
+		es[i] = l.PushBack(v)
+	}
+	return l, es
+}
+
+// check verifies Len and both traversal directions, by iterator and by
+// following the links.
+func check(t *testing.T, name string, l *List[int], want ...int) {
+	t.Helper()
+	if l.Len() != len(want) {
+		t.Fatalf("%s: Len = %d, want %d", name, l.Len(), len(want))
+	}
+	if got := slices.Collect(l.All()); !slices.Equal(got, want) {
+		t.Fatalf("%s: All = %v, want %v", name, got, want)
+	}
+	back := slices.Clone(want)
+	slices.Reverse(back)
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, back) {
+		t.Fatalf("%s: Backward = %v, want %v", name, got, back)
+	}
+	var fwd, bwd []int
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.list != l {
+			t.Fatalf("%s: element %d belongs to another list", name, e.Value)
+		}
+		fwd = append(fwd, e.Value)
+	}
+	for e := l.Back(); e != nil; e = e.Prev() {
+		bwd = append(bwd, e.Value)
+	}
+	if !slices.Equal(fwd, want) || !slices.Equal(bwd, back) {
+		t.Fatalf("%s: links give %v and %v, want %v", name, fwd, bwd, want)
+	}
+}
+
+func TestSpliceEmpty(t *testing.T) {
+	l, es := build(1, 2, 3)
+	empty := New[int]()
+	l.SpliceBack(empty.Front(), empty.Back(), empty)
+	l.SpliceFront(empty.Front(), empty.Back(), empty)
+	l.SpliceBefore(es[1], empty.Front(), empty.Back(), empty)
+	check(t, "from empty", l, 1, 2, 3)
+	check(t, "empty source", empty)
+
+	// Into the zero value, then back into the emptied list.
+	var zero List[int]
+	zero.SpliceBack(l.Front(), l.Back(), l)
+	check(t, "into zero value", &zero, 1, 2, 3)
+	check(t, "drained", l)
+	l.SpliceFront(zero.Front(), zero.Back(), &zero)
+	check(t, "back again", l, 1, 2, 3)
+	check(t, "zero drained", &zero)
+	if es[0].list != l {
+		t.Fatal("handle not re-homed")
+	}
+}
+
+func TestSpliceHeadAndTail(t *testing.T) {
+	tests := []struct {
+		name   string
+		splice func(dst, src *List[int], d, s []*Element[int])
+		dst    []int
+		src    []int
+	}{
+		{"front", func(dst, src *List[int], d, s []*Element[int]) { dst.SpliceFront(s[1], s[2], src) },
+			[]int{11, 12, 1, 2, 3}, []int{10, 13}},
+		{"back", func(dst, src *List[int], d, s []*Element[int]) { dst.SpliceBack(s[0], s[1], src) },
+			[]int{1, 2, 3, 10, 11}, []int{12, 13}},
+		{"before head", func(dst, src *List[int], d, s []*Element[int]) { dst.SpliceBefore(d[0], s[3], s[3], src) },
+			[]int{13, 1, 2, 3}, []int{10, 11, 12}},
+		{"before tail", func(dst, src *List[int], d, s []*Element[int]) { dst.SpliceBefore(d[2], s[0], s[3], src) },
+			[]int{1, 2, 10, 11, 12, 13, 3}, nil},
+		{"source head to tail", func(dst, src *List[int], d, s []*Element[int]) { dst.SpliceBack(s[0], s[0], src) },
+			[]int{1, 2, 3, 10}, []int{11, 12, 13}},
+		{"source tail to head", func(dst, src *List[int], d, s []*Element[int]) { dst.SpliceFront(s[3], s[3], src) },
+			[]int{13, 1, 2, 3}, []int{10, 11, 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst, d := build(1, 2, 3)
+			src, s := build(10, 11, 12, 13)
+			tt.splice(dst, src, d, s)
+			check(t, "dst", dst, tt.dst...)
+			check(t, "src", src, tt.src...)
+		})
+	}
+}
+
+func TestSpliceWithinList(t *testing.T) {
+	tests := []struct {
+		name   string
+		splice func(l *List[int], e []*Element[int])
+		want   []int
+	}{
+		// Runs that are already in place: the no-op cases.
+		{"whole list to back", func(l *List[int], e []*Element[int]) { l.SpliceBack(e[0], e[4], l) }, []int{1, 2, 3, 4, 5}},
+		{"whole list to front", func(l *List[int], e []*Element[int]) { l.SpliceFront(e[0], e[4], l) }, []int{1, 2, 3, 4, 5}},
+		{"tail to back", func(l *List[int], e []*Element[int]) { l.SpliceBack(e[3], e[4], l) }, []int{1, 2, 3, 4, 5}},
+		{"head to front", func(l *List[int], e []*Element[int]) { l.SpliceFront(e[0], e[1], l) }, []int{1, 2, 3, 4, 5}},
+		{"before its successor", func(l *List[int], e []*Element[int]) { l.SpliceBefore(e[3], e[1], e[2], l) }, []int{1, 2, 3, 4, 5}},
+
+		// Runs that move.
+		{"head to back", func(l *List[int], e []*Element[int]) { l.SpliceBack(e[0], e[1], l) }, []int{3, 4, 5, 1, 2}},
+		{"tail to front", func(l *List[int], e []*Element[int]) { l.SpliceFront(e[3], e[4], l) }, []int{4, 5, 1, 2, 3}},
+		{"middle to front", func(l *List[int], e []*Element[int]) { l.SpliceFront(e[2], e[2], l) }, []int{3, 1, 2, 4, 5}},
+		{"middle to back", func(l *List[int], e []*Element[int]) { l.SpliceBack(e[1], e[2], l) }, []int{1, 4, 5, 2, 3}},
+		{"backwards", func(l *List[int], e []*Element[int]) { l.SpliceBefore(e[1], e[3], e[4], l) }, []int{1, 4, 5, 2, 3}},
+		{"forwards", func(l *List[int], e []*Element[int]) { l.SpliceBefore(e[4], e[0], e[1], l) }, []int{3, 4, 1, 2, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, e := build(1, 2, 3, 4, 5)
+			tt.splice(l, e)
+			check(t, tt.name, l, tt.want...)
+		})
+	}
+}
+
+func TestSplicePanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		splice func(l *List[int], e []*Element[int])
+	}{
+		{"mark inside run", func(l *List[int], e []*Element[int]) { l.SpliceBefore(e[2], e[1], e[3], l) }},
+		{"last before first", func(l *List[int], e []*Element[int]) { l.SpliceBack(e[3], e[1], l) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, e := build(1, 2, 3, 4, 5)
+			defer func() {
+				if recover() == nil {
+					t.Fatal("no panic")
+				}
+				check(t, "after panic", l, 1, 2, 3, 4, 5)
+			}()
+			tt.splice(l, e)
+		})
+	}
+}
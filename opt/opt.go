@@ -0,0 +1,213 @@
+// Package opt provides Option and Result, value types that carry
+// "maybe a value" and "a value or an error" through a chain of steps.
+//
+// They bridge the two shapes Go already uses:
+//   - (T, bool)  – the comma-ok idiom from Maps.go  -> Option[T]
+//   - (T, error) – functions like divide in Errors.go -> Result[T]
+//
+// Methods cannot have their own type parameters, so combinators that change
+// the element type (Map, AndThen, MapResult, AndThenResult) are functions.
+package opt
+
+import "fmt"
+
+// ----------------------
+// 1. Option
+// ----------------------
+
+// Option holds either a value (Some) or nothing (None).
+// The zero value is None.
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some wraps v.
+func Some[T any](v T) Option[T] {
+	return Option[T]{value: v, ok: true}
+}
+
+// None returns an empty Option.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// FromOK converts a (value, ok) pair.
+func FromOK[T any](v T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+// Lookup is the comma-ok map lookup v, ok := m[key] as an Option.
+func Lookup[K comparable, V any](m map[K]V, key K) Option[V] {
+	v, ok := m[key]
+	return FromOK(v, ok)
+}
+
+// Get returns the value and whether it is present, the comma-ok shape again.
+func (o Option[T]) Get() (T, bool) { return o.value, o.ok }
+
+// IsSome reports whether o holds a value.
+func (o Option[T]) IsSome() bool { return o.ok }
+
+// IsNone reports whether o is empty.
+func (o Option[T]) IsNone() bool { return !o.ok }
+
+// Unwrap returns the value and panics if there is none.
+func (o Option[T]) Unwrap() T {
+	if !o.ok {
+		panic("opt: Unwrap called on None")
+	}
+	return o.value
+}
+
+// UnwrapOr returns the value, or def if there is none.
+func (o Option[T]) UnwrapOr(def T) T {
+	if !o.ok {
+		return def
+	}
+	return o.value
+}
+
+// OrElse returns o if it holds a value, otherwise the result of f.
+func (o Option[T]) OrElse(f func() Option[T]) Option[T] {
+	if o.ok {
+		return o
+	}
+	return f()
+}
+
+// OkOr turns o into a Result, using err when o is None.
+// It panics if o is None and err is nil; a Some value never looks at err.
+func (o Option[T]) OkOr(err error) Result[T] {
+	if !o.ok {
+		if err == nil {
+			panic("opt: OkOr called on None with a nil error")
+		}
+		return Err[T](err)
+	}
+	return Ok(o.value)
+}
+
+// String formats o as Some(v) or None.
+func (o Option[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// Map applies f to the value of o, if any.
+func Map[T, U any](o Option[T], f func(T) U) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(f(o.value))
+}
+
+// AndThen chains a step that may itself produce nothing.
+func AndThen[T, U any](o Option[T], f func(T) Option[U]) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return f(o.value)
+}
+
+// ----------------------
+// 2. Result
+// ----------------------
+
+// Result holds either a value (Ok) or an error (Err).
+// The zero value is Ok with the zero T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok wraps a successful value.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err wraps a failure. It panics if err is nil: a Result with a nil
+// error would be Ok.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic("opt: Err called with a nil error")
+	}
+	return Result[T]{err: err}
+}
+
+// From converts a (value, err) pair, e.g. opt.From(divide(10, 2)).
+func From[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
+// Get returns the value and error, the usual Go shape again.
+func (r Result[T]) Get() (T, error) { return r.value, r.err }
+
+// IsOk reports whether r holds a value.
+func (r Result[T]) IsOk() bool { return r.err == nil }
+
+// Err returns the error, or nil.
+func (r Result[T]) Err() error { return r.err }
+
+// Unwrap returns the value and panics with the error if there is one.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value, or def on error.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// OrElse returns r if it succeeded, otherwise the result of f,
+// which can recover from the error or replace it.
+func (r Result[T]) OrElse(f func(error) Result[T]) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return f(r.err)
+}
+
+// Option drops the error: Ok becomes Some, Err becomes None.
+func (r Result[T]) Option() Option[T] {
+	return FromOK(r.value, r.err == nil)
+}
+
+// String formats r as Ok(v) or Err(message).
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult applies f to the value of r; errors pass through unchanged.
+func MapResult[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+// AndThenResult chains a step that may itself fail, like calling
+// divide on the result of another divide.
+func AndThenResult[T, U any](r Result[T], f func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return From(f(r.value))
+}
@@ -0,0 +1,118 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// MarshalJSON encodes the map as a JSON object with keys in map order.
+// Keys follow encoding/json rules: strings, integers, or encoding.TextMarshaler.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	i := 0
+	for k, v := range m.All() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		i++
+		name, err := keyString(k)
+		if err != nil {
+			return nil, err
+		}
+		kb, _ := json.Marshal(name)
+		vb, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object, keeping the order of its keys.
+// Existing entries are discarded. As with encoding/json, null is a no-op.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok == nil {
+		return nil
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("orderedmap: expected JSON object, got %v", tok)
+	}
+	*m = OrderedMap[K, V]{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var key K
+		if err := parseKey(tok.(string), &key); err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Set(key, value)
+	}
+	_, err := dec.Token() // closing '}'
+	return err
+}
+
+// keyString converts a key to its JSON object name. Like encoding/json,
+// it uses a string-kind key as is, even if it has a MarshalText method.
+func keyString(k any) (string, error) {
+	v := reflect.ValueOf(k)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	if tm, ok := k.(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("orderedmap: unsupported key type %T", k)
+}
+
+// parseKey is the inverse of keyString.
+func parseKey(s string, key any) error {
+	v := reflect.ValueOf(key).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(s)
+		return nil
+	}
+	if tu, ok := key.(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("orderedmap: key %q: %w", s, err)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("orderedmap: key %q: %w", s, err)
+		}
+		v.SetUint(n)
+		return nil
+	}
+	return fmt.Errorf("orderedmap: unsupported key type %s", v.Type())
+}
@@ -0,0 +1,138 @@
+// Package orderedmap provides a map that remembers insertion order.
+//
+// Ranging over a built-in map (Loops.go, Module.go) visits keys in random
+// order. OrderedMap keeps a linked list of entries beside the hash map, so
+// Get, Set and Delete stay O(1) while iteration follows insertion order.
+package orderedmap
+
+import (
+	"iter"
+	"linkedlist"
+)
+
+// OrderedMap is a map that iterates in insertion order.
+// The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	items map[K]*linkedlist.Element[entry[K, V]]
+	order linkedlist.List[entry[K, V]]
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New returns an empty map.
+func New[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+// ----------------------
+// 1. Map operations
+// ----------------------
+
+// Get returns the value for key, with the same comma-ok result as m[key].
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if el, ok := m.items[key]; ok {
+		return el.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has reports whether key is present.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.items[key]
+	return ok
+}
+
+// Set stores value under key. A new key goes to the back;
+// an existing key keeps its position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if el, ok := m.items[key]; ok {
+		el.Value.value = value
+		return
+	}
+	if m.items == nil {
+		m.items = make(map[K]*linkedlist.Element[entry[K, V]])
+	}
+	m.items[key] = m.order.PushBack(entry[K, V]{key, value})
+}
+
+// Delete removes key and reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	el, ok := m.items[key]
+	if ok {
+		m.order.Remove(el)
+		delete(m.items, key)
+	}
+	return ok
+}
+
+// Len returns the number of entries.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.items)
+}
+
+// ----------------------
+// 2. Reordering
+// ----------------------
+
+// MoveToFront makes key the first entry. It reports whether key was present.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	el, ok := m.items[key]
+	if ok {
+		m.order.MoveToFront(el)
+	}
+	return ok
+}
+
+// MoveToBack makes key the last entry. It reports whether key was present.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	el, ok := m.items[key]
+	if ok {
+		m.order.MoveToBack(el)
+	}
+	return ok
+}
+
+// ----------------------
+// 3. Iteration
+// ----------------------
+
+// All iterates from the first entry to the last.
+// Deleting the current entry during iteration is allowed.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for el := m.order.Front(); el != nil; {
+			next := el.Next()
+			if !yield(el.Value.key, el.Value.value) {
+				return
+			}
+			el = next
+		}
+	}
+}
+
+// Backward iterates from the last entry to the first.
+// Deleting the current entry during iteration is allowed.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for el := m.order.Back(); el != nil; {
+			prev := el.Prev()
+			if !yield(el.Value.key, el.Value.value) {
+				return
+			}
+			el = prev
+		}
+	}
+}
+
+// Keys returns the keys in order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,133 @@
+// Package panics turns panics into ordinary errors at well-defined boundaries.
+//
+// Defer.go and Misc.go show recover() printing to stdout. SafeCall and SafeGo
+// wrap the same defer/recover pattern so callers get a *PanicError back
+// instead, carrying the panic value and the stack of the panicking goroutine.
+package panics
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"runtime/debug"
+)
+
+// ----------------------
+// 1. The error type
+// ----------------------
+
+// PanicError is returned in place of a recovered panic.
+type PanicError struct {
+	Value any    // value passed to panic()
+	Stack []byte // stack trace captured inside the deferred recover
+}
+
+// Error describes the panic value.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it is itself an error,
+// so errors.Is / errors.As see through the panic.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// IsRuntime reports whether the panic came from the Go runtime
+// (nil dereference, index out of range, ...) rather than an explicit panic().
+func (e *PanicError) IsRuntime() bool {
+	var re runtime.Error
+	return errors.As(e.Unwrap(), &re)
+}
+
+// ----------------------
+// 2. Policy
+// ----------------------
+
+// Policy decides which panics a Boundary is allowed to swallow.
+type Policy int
+
+const (
+	// RecoverAll converts every panic into a *PanicError.
+	RecoverAll Policy = iota
+	// RepanicRuntimeErrors lets runtime errors continue unwinding,
+	// because they usually mean a programming bug rather than a bad input.
+	RepanicRuntimeErrors
+)
+
+// ----------------------
+// 3. Boundary
+// ----------------------
+
+// Boundary is a configured panic boundary.
+// Set its fields before use; they are not guarded against concurrent changes.
+type Boundary struct {
+	Policy Policy
+
+	// OnPanic reports panics recovered by Go, where there is no caller
+	// to return the error to. When nil, the panic is written with log.Printf.
+	OnPanic func(*PanicError)
+}
+
+// Default is the boundary used by SafeCall and SafeGo.
+var Default = &Boundary{}
+
+// Call runs f and returns its error, or a *PanicError if f panicked.
+func (b *Boundary) Call(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = b.convert(r)
+		}
+	}()
+	return f()
+}
+
+// Go runs f in a new goroutine. A panic in f is recovered and passed to
+// OnPanic instead of crashing the program.
+func (b *Boundary) Go(f func()) {
+	go func() {
+		err := b.Call(func() error {
+			f()
+			return nil
+		})
+		var pe *PanicError
+		if errors.As(err, &pe) {
+			b.report(pe)
+		}
+	}()
+}
+
+// convert builds the *PanicError for r, or re-raises r if the policy says so.
+func (b *Boundary) convert(r any) *PanicError {
+	pe := &PanicError{Value: r, Stack: debug.Stack()}
+	if b.Policy == RepanicRuntimeErrors && pe.IsRuntime() {
+		panic(r)
+	}
+	return pe
+}
+
+func (b *Boundary) report(pe *PanicError) {
+	if b.OnPanic != nil {
+		b.OnPanic(pe)
+		return
+	}
+	log.Printf("recovered %v\n%s", pe, pe.Stack)
+}
+
+// ----------------------
+// 4. Package-level helpers
+// ----------------------
+
+// SafeCall runs f under the Default boundary.
+func SafeCall(f func() error) error {
+	return Default.Call(f)
+}
+
+// SafeGo runs f in a goroutine under the Default boundary.
+func SafeGo(f func()) {
+	Default.Go(f)
+}
@@ -0,0 +1,327 @@
+package persistent
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+)
+
+// ----------------------
+// 1. HashMap
+// ----------------------
+
+// slot is either a key/value pair or a pointer to a sub-node.
+type slot[K comparable, V any] struct {
+	node *hnode[K, V] // non-nil for a sub-node
+	hash uint64
+	key  K
+	val  V
+}
+
+// hnode is a bitmap-indexed node of the trie: bit i of bitmap is set when
+// the 5-bit hash chunk i is present, and slots holds only those entries.
+// Past the last hash chunk, colliding keys are kept in a plain list.
+type hnode[K comparable, V any] struct {
+	edit   *owner
+	bitmap uint32
+	slots  []slot[K, V]
+}
+
+// HashMap is an immutable hash map implemented as a hash array mapped trie
+// (HAMT). The zero value is an empty map.
+type HashMap[K comparable, V any] struct {
+	root *hnode[K, V]
+	size int
+	seed maphash.Seed
+}
+
+// NewHashMap returns an empty map. Every version derived from it shares
+// its hash seed; the zero value picks a seed on its first update instead.
+func NewHashMap[K comparable, V any]() HashMap[K, V] {
+	return HashMap[K, V]{root: &hnode[K, V]{}, seed: maphash.MakeSeed()}
+}
+
+// Len returns the number of entries.
+func (m HashMap[K, V]) Len() int { return m.size }
+
+// Get returns the value for key, with the comma-ok result of a built-in map.
+func (m HashMap[K, V]) Get(key K) (V, bool) {
+	if m.root == nil {
+		var zero V
+		return zero, false
+	}
+	return m.lookup(m.hash(key), key)
+}
+
+// Set returns a new map with key set to val.
+func (m HashMap[K, V]) Set(key K, val V) HashMap[K, V] {
+	m = m.init()
+	return m.set(nil, m.hash(key), key, val)
+}
+
+// Delete returns a new map without key. If key is absent, m is returned as is.
+func (m HashMap[K, V]) Delete(key K) HashMap[K, V] {
+	if m.root == nil {
+		return m
+	}
+	return m.delete(nil, m.hash(key), key)
+}
+
+// All iterates over the entries in unspecified (but stable per version) order.
+func (m HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.each(yield)
+		}
+	}
+}
+
+// ----------------------
+// 2. Transient map
+// ----------------------
+
+// TransientHashMap batches updates to a HashMap. It is not safe for
+// concurrent use and must not be used after Persistent.
+type TransientHashMap[K comparable, V any] struct {
+	m    HashMap[K, V]
+	edit *owner
+}
+
+// Transient returns a builder starting from m. m itself is not affected.
+func (m HashMap[K, V]) Transient() *TransientHashMap[K, V] {
+	return &TransientHashMap[K, V]{m: m.init(), edit: &owner{}}
+}
+
+// Len returns the number of entries.
+func (t *TransientHashMap[K, V]) Len() int { return t.m.size }
+
+// Get returns the value for key.
+func (t *TransientHashMap[K, V]) Get(key K) (V, bool) { return t.m.Get(key) }
+
+// Set stores val under key, in place.
+func (t *TransientHashMap[K, V]) Set(key K, val V) {
+	t.check()
+	t.m = t.m.set(t.edit, t.m.hash(key), key, val)
+}
+
+// Delete removes key, in place.
+func (t *TransientHashMap[K, V]) Delete(key K) {
+	t.check()
+	t.m = t.m.delete(t.edit, t.m.hash(key), key)
+}
+
+// Persistent freezes the builder and returns the resulting map.
+func (t *TransientHashMap[K, V]) Persistent() HashMap[K, V] {
+	t.check()
+	t.edit = nil
+	return t.m
+}
+
+func (t *TransientHashMap[K, V]) check() {
+	if t.edit == nil {
+		panic("persistent: transient used after Persistent")
+	}
+}
+
+// ----------------------
+// 3. HAMT internals
+// ----------------------
+
+// init gives the zero map its root node and hash seed.
+func (m HashMap[K, V]) init() HashMap[K, V] {
+	if m.root == nil {
+		m.root, m.seed = &hnode[K, V]{}, maphash.MakeSeed()
+	}
+	return m
+}
+
+func (m HashMap[K, V]) hash(key K) uint64 { return maphash.Comparable(m.seed, key) }
+
+// lookup, set and delete take the hash of key from the caller, so tests
+// can force collisions.
+func (m HashMap[K, V]) lookup(h uint64, key K) (V, bool) {
+	n := m.root
+	for shift := uint(0); ; shift += bitsPerLevel {
+		if shift >= 64 {
+			for _, s := range n.slots {
+				if s.key == key {
+					return s.val, true
+				}
+			}
+			break
+		}
+		bit := uint32(1) << ((h >> shift) & mask)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		s := n.slots[n.index(bit)]
+		if s.node == nil {
+			if s.hash == h && s.key == key {
+				return s.val, true
+			}
+			break
+		}
+		n = s.node
+	}
+	var zero V
+	return zero, false
+}
+
+func (m HashMap[K, V]) set(edit *owner, h uint64, key K, val V) HashMap[K, V] {
+	root, added := m.root.assoc(edit, 0, slot[K, V]{hash: h, key: key, val: val})
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+func (m HashMap[K, V]) delete(edit *owner, h uint64, key K) HashMap[K, V] {
+	root, removed := m.root.without(edit, 0, h, key)
+	if !removed {
+		return m
+	}
+	if root == nil {
+		root = &hnode[K, V]{edit: edit}
+	}
+	m.root = root
+	m.size--
+	return m
+}
+
+// index returns the position in slots of the entry for bit.
+func (n *hnode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hnode[K, V]) editable(edit *owner) *hnode[K, V] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	return &hnode[K, V]{edit: edit, bitmap: n.bitmap, slots: append([]slot[K, V](nil), n.slots...)}
+}
+
+// assoc inserts or replaces leaf below n and reports whether a key was added.
+func (n *hnode[K, V]) assoc(edit *owner, shift uint, leaf slot[K, V]) (*hnode[K, V], bool) {
+	if shift >= 64 { // collision list
+		for i, s := range n.slots {
+			if s.key == leaf.key {
+				c := n.editable(edit)
+				c.slots[i] = leaf
+				return c, false
+			}
+		}
+		c := n.editable(edit)
+		c.slots = append(c.slots, leaf)
+		return c, true
+	}
+
+	bit := uint32(1) << ((leaf.hash >> shift) & mask)
+	i := n.index(bit)
+	if n.bitmap&bit == 0 {
+		c := n.editable(edit)
+		c.slots = append(c.slots, slot[K, V]{})
+		copy(c.slots[i+1:], c.slots[i:])
+		c.slots[i] = leaf
+		c.bitmap |= bit
+		return c, true
+	}
+
+	s := n.slots[i]
+	var added bool
+	switch {
+	case s.node != nil:
+		var child *hnode[K, V]
+		child, added = s.node.assoc(edit, shift+bitsPerLevel, leaf)
+		s = slot[K, V]{node: child}
+	case s.hash == leaf.hash && s.key == leaf.key:
+		s = leaf
+	default:
+		s = slot[K, V]{node: merge(edit, shift+bitsPerLevel, s, leaf)}
+		added = true
+	}
+	c := n.editable(edit)
+	c.slots[i] = s
+	return c, added
+}
+
+// merge builds the smallest sub-trie holding two different keys.
+func merge[K comparable, V any](edit *owner, shift uint, a, b slot[K, V]) *hnode[K, V] {
+	if shift >= 64 {
+		return &hnode[K, V]{edit: edit, slots: []slot[K, V]{a, b}}
+	}
+	ia, ib := (a.hash>>shift)&mask, (b.hash>>shift)&mask
+	if ia == ib {
+		child := merge(edit, shift+bitsPerLevel, a, b)
+		return &hnode[K, V]{edit: edit, bitmap: 1 << ia, slots: []slot[K, V]{{node: child}}}
+	}
+	if ia > ib {
+		a, b = b, a
+	}
+	return &hnode[K, V]{edit: edit, bitmap: 1<<ia | 1<<ib, slots: []slot[K, V]{a, b}}
+}
+
+// without removes key below n. It returns nil when n ends up empty, and
+// reports whether anything was removed.
+func (n *hnode[K, V]) without(edit *owner, shift uint, h uint64, key K) (*hnode[K, V], bool) {
+	if shift >= 64 {
+		for i, s := range n.slots {
+			if s.key == key {
+				return n.removeSlot(edit, i, 0), true
+			}
+		}
+		return n, false
+	}
+
+	bit := uint32(1) << ((h >> shift) & mask)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.index(bit)
+	s := n.slots[i]
+	if s.node == nil {
+		if s.hash != h || s.key != key {
+			return n, false
+		}
+		return n.removeSlot(edit, i, bit), true
+	}
+
+	child, removed := s.node.without(edit, shift+bitsPerLevel, h, key)
+	if !removed {
+		return n, false
+	}
+	if child == nil {
+		return n.removeSlot(edit, i, bit), true
+	}
+	c := n.editable(edit)
+	if len(child.slots) == 1 && child.slots[0].node == nil {
+		c.slots[i] = child.slots[0] // pull a lone key up to keep the trie shallow
+	} else {
+		c.slots[i] = slot[K, V]{node: child}
+	}
+	return c, true
+}
+
+func (n *hnode[K, V]) removeSlot(edit *owner, i int, bit uint32) *hnode[K, V] {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	c := n.editable(edit)
+	c.slots = append(c.slots[:i], c.slots[i+1:]...)
+	c.slots[len(c.slots):cap(c.slots)][0] = slot[K, V]{} // drop the stale reference
+	c.bitmap &^= bit
+	return c
+}
+
+func (n *hnode[K, V]) each(yield func(K, V) bool) bool {
+	for _, s := range n.slots {
+		if s.node != nil {
+			if !s.node.each(yield) {
+				return false
+			}
+		} else if !yield(s.key, s.val) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package persistent
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func ints(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+func checkVector(t *testing.T, v Vector[int], want []int) {
+	t.Helper()
+	if v.Len() != len(want) {
+		t.Fatalf("Len = %d, want %d", v.Len(), len(want))
+	}
+	for i, x := range want {
+		if got := v.Get(i); got != x {
+			t.Fatalf("Get(%d) = %d, want %d", i, got, x)
+		}
+	}
+	if got := v.Slice(); !slices.Equal(got, want) {
+		t.Fatalf("Slice = %v, want %v", got, want)
+	}
+}
+
+// The tail holds up to 32 elements, a root of depth one holds 32 leaves,
+// so the shape of the trie changes at 33 and again at 1057.
+func TestVectorBoundaries(t *testing.T) {
+	for _, n := range []int{0, 1, 31, 32, 33, 64, 65, 1055, 1056, 1057, 1088, 1089, 32*32*32 + 33} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			var v Vector[int]
+			for i := range n {
+				v = v.Append(i)
+			}
+			want := ints(n)
+			checkVector(t, v, want)
+			checkVector(t, VectorOf(want...), want)
+
+			for i := range n {
+				v = v.Set(i, -i)
+			}
+			for i := range want {
+				want[i] = -i
+			}
+			checkVector(t, v, want)
+
+			for len(want) > 0 {
+				v = v.Pop()
+				want = want[:len(want)-1]
+				if len(want)%32 <= 1 || len(want) > n-3 {
+					checkVector(t, v, want)
+				}
+			}
+			checkVector(t, v, nil)
+		})
+	}
+}
+
+func TestVectorOldVersionsUnchanged(t *testing.T) {
+	var versions []Vector[int]
+	var v Vector[int]
+	for i := range 1100 {
+		versions = append(versions, v)
+		v = v.Append(i)
+	}
+	for i := 0; i < 1100; i += 7 {
+		v = v.Set(i, -1)
+	}
+	tr := v.Transient()
+	for range 500 {
+		tr.Pop()
+	}
+	tr.Set(0, 42)
+	tr.Persistent()
+
+	for n, old := range versions {
+		checkVector(t, old, ints(n))
+	}
+	for i := range 1100 {
+		want := i
+		if i%7 == 0 {
+			want = -1
+		}
+		if got := v.Get(i); got != want {
+			t.Fatalf("v.Get(%d) = %d after a transient built from v, want %d", i, got, want)
+		}
+	}
+}
+
+func TestTransientUsedAfterPersistentPanics(t *testing.T) {
+	tv := VectorOf(1, 2, 3).Transient()
+	tv.Persistent()
+	tm := NewHashMap[string, int]().Transient()
+	tm.Persistent()
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"Vector.Append", func() { tv.Append(4) }},
+		{"Vector.Set", func() { tv.Set(0, 4) }},
+		{"Vector.Pop", func() { tv.Pop() }},
+		{"Vector.Persistent", func() { tv.Persistent() }},
+		{"HashMap.Set", func() { tm.Set("a", 1) }},
+		{"HashMap.Delete", func() { tm.Delete("a") }},
+		{"HashMap.Persistent", func() { tm.Persistent() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("no panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
+
+func TestHashMapZeroValue(t *testing.T) {
+	var m HashMap[string, int]
+	if _, ok := m.Get("a"); ok || m.Len() != 0 {
+		t.Fatal("zero map is not empty")
+	}
+	if m.Delete("a").Len() != 0 {
+		t.Fatal("Delete on the zero map added something")
+	}
+	for range m.All() {
+		t.Fatal("zero map yielded an entry")
+	}
+	m1 := m.Set("a", 1)
+	if v, ok := m1.Get("a"); !ok || v != 1 || m1.Len() != 1 {
+		t.Fatalf("Get(a) = %d, %v after Set", v, ok)
+	}
+	if m.Len() != 0 {
+		t.Fatal("Set changed the zero map")
+	}
+	tr := m.Transient()
+	tr.Set("b", 2)
+	if m2 := tr.Persistent(); m2.Len() != 1 {
+		t.Fatalf("Len = %d after a transient Set", m2.Len())
+	}
+}
+
+// checkMap compares m against the built-in map want, using hash to find
+// each key's hash.
+func checkMap(t *testing.T, m HashMap[string, int], want map[string]int, hash func(string) uint64) {
+	t.Helper()
+	if m.Len() != len(want) {
+		t.Fatalf("Len = %d, want %d", m.Len(), len(want))
+	}
+	for k, v := range want {
+		if got, ok := m.lookup(hash(k), k); !ok || got != v {
+			t.Fatalf("lookup(%q) = %d, %v, want %d", k, got, ok, v)
+		}
+	}
+	if got := maps.Collect(m.All()); !maps.Equal(got, want) {
+		t.Fatalf("All = %v, want %v", got, want)
+	}
+}
+
+// TestHashMapPaths drives the trie with chosen hashes, so that keys share
+// prefixes or collide outright, and checks inserts, removals and old versions.
+func TestHashMapPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		hash func(i int) uint64
+	}{
+		{"full collision", func(i int) uint64 { return 0xdeadbeef }},
+		{"collision pairs", func(i int) uint64 { return uint64(i / 2) }},
+		{"shared prefix", func(i int) uint64 { return uint64(i) << 55 }},
+		{"last chunk", func(i int) uint64 { return uint64(i%3) << 60 }},
+		{"spread", func(i int) uint64 { return uint64(i) * 0x9e3779b97f4a7c15 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashes := map[string]uint64{}
+			hash := func(k string) uint64 { return hashes[k] }
+			for i := range 40 {
+				hashes[fmt.Sprint("k", i)] = tt.hash(i)
+			}
+
+			rng := rand.New(rand.NewPCG(1, 2))
+			m := NewHashMap[string, int]()
+			want := map[string]int{}
+			var versions []HashMap[string, int]
+			var wants []map[string]int
+			for step := range 400 {
+				k := fmt.Sprint("k", rng.IntN(40))
+				if rng.IntN(3) == 0 {
+					m = m.delete(nil, hash(k), k)
+					delete(want, k)
+				} else {
+					m = m.set(nil, hash(k), k, step)
+					want[k] = step
+				}
+				versions = append(versions, m)
+				wants = append(wants, maps.Clone(want))
+				checkMap(t, m, want, hash)
+			}
+			for i, old := range versions {
+				checkMap(t, old, wants[i], hash)
+			}
+
+			// Delete everything through a transient, leaving m intact.
+			tr := m.Transient()
+			for k := range want {
+				tr.m = tr.m.delete(tr.edit, hash(k), k)
+				if _, ok := tr.m.lookup(hash(k), k); ok {
+					t.Fatalf("%q still present after delete", k)
+				}
+			}
+			checkMap(t, tr.Persistent(), map[string]int{}, hash)
+			checkMap(t, m, want, hash)
+		})
+	}
+}
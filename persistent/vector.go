@@ -0,0 +1,331 @@
+// Package persistent provides immutable collections with structural sharing.
+//
+// Maps.go shows how two slices sharing a backing array can surprise each
+// other. Here every update returns a new version and leaves the old one
+// untouched, yet the two share all unchanged nodes, so an update costs
+// O(log32 n) instead of a full copy. Old versions are safe to keep as
+// snapshots and to read from any number of goroutines.
+//
+// For many updates in a row, a transient builder (Vector.Transient,
+// HashMap.Transient) mutates nodes it created itself in place and then
+// freezes them into a new persistent version.
+package persistent
+
+import "iter"
+
+const (
+	bitsPerLevel = 5
+	width        = 1 << bitsPerLevel // 32-way branching
+	mask         = width - 1
+)
+
+// owner identifies the transient allowed to mutate a node in place.
+// Persistent operations pass a nil owner and therefore always copy.
+// It must not be zero-sized: pointers to zero-sized values may compare equal.
+type owner struct{ _ byte }
+
+// ----------------------
+// 1. Vector
+// ----------------------
+
+type vnode[T any] struct {
+	edit *owner
+	kids []*vnode[T] // branch nodes: width children
+	vals []T         // leaf nodes: width values
+}
+
+// Vector is an immutable indexed sequence: a 32-way trie of leaves plus a
+// "tail" leaf holding the last up-to-32 elements, which makes Append cheap.
+// The zero value is an empty vector.
+type Vector[T any] struct {
+	cnt      int
+	shift    uint
+	root     *vnode[T]
+	tail     []T
+	tailEdit *owner // transient that may append to tail in place
+}
+
+// VectorOf returns a vector holding items.
+func VectorOf[T any](items ...T) Vector[T] {
+	t := Vector[T]{}.Transient()
+	for _, v := range items {
+		t.Append(v)
+	}
+	return t.Persistent()
+}
+
+// Len returns the number of elements.
+func (v Vector[T]) Len() int { return v.cnt }
+
+// Get returns element i. It panics if i is out of range.
+func (v Vector[T]) Get(i int) T {
+	return v.leafFor(i)[i&mask]
+}
+
+// Append returns a new vector with x added at the end.
+func (v Vector[T]) Append(x T) Vector[T] {
+	return v.conj(nil, x)
+}
+
+// Set returns a new vector with element i replaced by x.
+// i == Len() appends. It panics if i is out of range.
+func (v Vector[T]) Set(i int, x T) Vector[T] {
+	return v.assoc(nil, i, x)
+}
+
+// Pop returns a new vector without its last element. It panics if v is empty.
+func (v Vector[T]) Pop() Vector[T] {
+	return v.pop(nil)
+}
+
+// All iterates over the elements in index order.
+func (v Vector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < v.cnt; i += width {
+			leaf := v.leafFor(i)
+			for j := 0; j < len(leaf) && i+j < v.cnt; j++ {
+				if !yield(i+j, leaf[j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Slice copies the elements into a new slice.
+func (v Vector[T]) Slice() []T {
+	out := make([]T, 0, v.cnt)
+	for _, x := range v.All() {
+		out = append(out, x)
+	}
+	return out
+}
+
+// ----------------------
+// 2. Transient vector
+// ----------------------
+
+// TransientVector batches updates to a Vector. It is not safe for concurrent
+// use and must not be used after Persistent.
+type TransientVector[T any] struct {
+	v    Vector[T]
+	edit *owner
+}
+
+// Transient returns a builder starting from v. v itself is not affected.
+func (v Vector[T]) Transient() *TransientVector[T] {
+	return &TransientVector[T]{v: v, edit: &owner{}}
+}
+
+// Len returns the number of elements.
+func (t *TransientVector[T]) Len() int { return t.v.cnt }
+
+// Get returns element i.
+func (t *TransientVector[T]) Get(i int) T { return t.v.Get(i) }
+
+// Append adds x at the end, in place.
+func (t *TransientVector[T]) Append(x T) { t.check(); t.v = t.v.conj(t.edit, x) }
+
+// Set replaces element i with x, in place.
+func (t *TransientVector[T]) Set(i int, x T) { t.check(); t.v = t.v.assoc(t.edit, i, x) }
+
+// Pop removes the last element, in place.
+func (t *TransientVector[T]) Pop() { t.check(); t.v = t.v.pop(t.edit) }
+
+// Persistent freezes the builder and returns the resulting vector.
+func (t *TransientVector[T]) Persistent() Vector[T] {
+	t.check()
+	t.edit = nil
+	return t.v
+}
+
+func (t *TransientVector[T]) check() {
+	if t.edit == nil {
+		panic("persistent: transient used after Persistent")
+	}
+}
+
+// ----------------------
+// 3. Vector internals
+// ----------------------
+
+// tailOffset is the index of the first element stored in the tail.
+func (v Vector[T]) tailOffset() int {
+	if v.cnt < width {
+		return 0
+	}
+	return ((v.cnt - 1) >> bitsPerLevel) << bitsPerLevel
+}
+
+func (v Vector[T]) leafFor(i int) []T {
+	if i < 0 || i >= v.cnt {
+		panic("persistent: index out of range")
+	}
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= bitsPerLevel {
+		n = n.kids[(i>>level)&mask]
+	}
+	return n.vals
+}
+
+// editable returns n itself if edit owns it, otherwise a copy owned by edit.
+func (n *vnode[T]) editable(edit *owner) *vnode[T] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	c := &vnode[T]{edit: edit}
+	if n.kids != nil {
+		c.kids = append([]*vnode[T](nil), n.kids...)
+	}
+	if n.vals != nil {
+		c.vals = append([]T(nil), n.vals...)
+	}
+	return c
+}
+
+func newBranch[T any](edit *owner) *vnode[T] {
+	return &vnode[T]{edit: edit, kids: make([]*vnode[T], width)}
+}
+
+func (v Vector[T]) conj(edit *owner, x T) Vector[T] {
+	if v.root == nil {
+		v.root, v.shift = newBranch[T](nil), bitsPerLevel
+	}
+	if v.cnt-v.tailOffset() < width {
+		if edit == nil || v.tailEdit != edit {
+			tail := make([]T, len(v.tail), width)
+			copy(tail, v.tail)
+			v.tail, v.tailEdit = tail, edit
+		}
+		v.tail = append(v.tail, x)
+		v.cnt++
+		return v
+	}
+
+	// The tail is full: push it into the trie and start a new one.
+	// The leaf is only mutable by whoever owned the tail, so a transient
+	// never writes into a tail still shared with a persistent version.
+	leaf := &vnode[T]{edit: v.tailEdit, vals: v.tail}
+	if (v.cnt >> bitsPerLevel) > (1 << v.shift) { // the root itself is full
+		root := newBranch[T](edit)
+		root.kids[0] = v.root
+		root.kids[1] = newPath(edit, v.shift, leaf)
+		v.root, v.shift = root, v.shift+bitsPerLevel
+	} else {
+		v.root = v.pushTail(edit, v.shift, v.root, leaf)
+	}
+	v.tail = make([]T, 1, width)
+	v.tail[0], v.tailEdit = x, edit
+	v.cnt++
+	return v
+}
+
+func (v Vector[T]) pushTail(edit *owner, level uint, parent, leaf *vnode[T]) *vnode[T] {
+	n := parent.editable(edit)
+	sub := ((v.cnt - 1) >> level) & mask
+	switch {
+	case level == bitsPerLevel:
+		n.kids[sub] = leaf
+	case parent.kids[sub] != nil:
+		n.kids[sub] = v.pushTail(edit, level-bitsPerLevel, parent.kids[sub], leaf)
+	default:
+		n.kids[sub] = newPath(edit, level-bitsPerLevel, leaf)
+	}
+	return n
+}
+
+func newPath[T any](edit *owner, level uint, leaf *vnode[T]) *vnode[T] {
+	if level == 0 {
+		return leaf
+	}
+	n := newBranch[T](edit)
+	n.kids[0] = newPath(edit, level-bitsPerLevel, leaf)
+	return n
+}
+
+func (v Vector[T]) assoc(edit *owner, i int, x T) Vector[T] {
+	if i == v.cnt {
+		return v.conj(edit, x)
+	}
+	if i < 0 || i > v.cnt {
+		panic("persistent: index out of range")
+	}
+	if i >= v.tailOffset() {
+		if edit == nil || v.tailEdit != edit {
+			tail := make([]T, len(v.tail), width)
+			copy(tail, v.tail)
+			v.tail, v.tailEdit = tail, edit
+		}
+		v.tail[i&mask] = x
+		return v
+	}
+	v.root = doAssoc(edit, v.shift, v.root, i, x)
+	return v
+}
+
+func doAssoc[T any](edit *owner, level uint, node *vnode[T], i int, x T) *vnode[T] {
+	n := node.editable(edit)
+	if level == 0 {
+		n.vals[i&mask] = x
+	} else {
+		sub := (i >> level) & mask
+		n.kids[sub] = doAssoc(edit, level-bitsPerLevel, node.kids[sub], i, x)
+	}
+	return n
+}
+
+func (v Vector[T]) pop(edit *owner) Vector[T] {
+	switch {
+	case v.cnt == 0:
+		panic("persistent: Pop on empty vector")
+	case v.cnt == 1:
+		return Vector[T]{}
+	case v.cnt-v.tailOffset() > 1:
+		var zero T
+		if edit != nil && v.tailEdit == edit {
+			v.tail[len(v.tail)-1] = zero // drop the reference in place
+			v.tail = v.tail[:len(v.tail)-1]
+		} else {
+			v.tail = v.tail[: len(v.tail)-1 : len(v.tail)-1]
+			v.tailEdit = nil
+		}
+		v.cnt--
+		return v
+	}
+
+	// The tail empties: the last leaf of the trie becomes the new tail.
+	newTail := v.leafFor(v.cnt - 2)
+	root := v.popTail(edit, v.shift, v.root)
+	if root == nil {
+		root = newBranch[T](edit)
+	}
+	if v.shift > bitsPerLevel && root.kids[1] == nil {
+		root = root.kids[0]
+		v.shift -= bitsPerLevel
+	}
+	v.root, v.tail, v.tailEdit = root, newTail[:width:width], nil
+	v.cnt--
+	return v
+}
+
+func (v Vector[T]) popTail(edit *owner, level uint, node *vnode[T]) *vnode[T] {
+	sub := ((v.cnt - 2) >> level) & mask
+	if level > bitsPerLevel {
+		child := v.popTail(edit, level-bitsPerLevel, node.kids[sub])
+		if child == nil && sub == 0 {
+			return nil
+		}
+		n := node.editable(edit)
+		n.kids[sub] = child
+		return n
+	}
+	if sub == 0 {
+		return nil
+	}
+	n := node.editable(edit)
+	n.kids[sub] = nil
+	return n
+}
@@ -0,0 +1,162 @@
+// Package pqueue is a typed binary-heap priority queue with handles.
+//
+// Unlike container/heap there is no interface{} plumbing: the caller supplies
+// a less function, and every Push returns a Handle that can later be used to
+// change the element's priority or remove it in O(log n).
+package pqueue
+
+// Handle refers to one element in a PriorityQueue.
+type Handle[T any] struct {
+	value T
+	index int // position in the heap, -1 once removed
+}
+
+// Value returns the element the handle refers to.
+func (h *Handle[T]) Value() T { return h.value }
+
+// Queued reports whether the element is still in the queue.
+func (h *Handle[T]) Queued() bool { return h.index >= 0 }
+
+// PriorityQueue pops the element that is "least" according to less first,
+// so less(a, b) = a.Priority < b.Priority gives a min-queue.
+type PriorityQueue[T any] struct {
+	heap []*Handle[T]
+	less func(a, b T) bool
+}
+
+// New returns an empty queue ordered by less.
+func New[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Heapify builds a queue from items in O(n) and returns the handles in the
+// same order as items. items itself is not modified.
+func Heapify[T any](items []T, less func(a, b T) bool) (*PriorityQueue[T], []*Handle[T]) {
+	pq := &PriorityQueue[T]{heap: make([]*Handle[T], len(items)), less: less}
+	handles := make([]*Handle[T], len(items))
+	for i, v := range items {
+		h := &Handle[T]{value: v, index: i}
+		pq.heap[i], handles[i] = h, h
+	}
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	return pq, handles
+}
+
+// ----------------------
+// 1. Queue operations
+// ----------------------
+
+// Len returns the number of queued elements.
+func (pq *PriorityQueue[T]) Len() int { return len(pq.heap) }
+
+// Push adds v and returns its handle.
+func (pq *PriorityQueue[T]) Push(v T) *Handle[T] {
+	h := &Handle[T]{value: v, index: len(pq.heap)}
+	pq.heap = append(pq.heap, h)
+	pq.up(h.index)
+	return h
+}
+
+// Peek returns the highest-priority element without removing it.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.heap) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.heap[0].value, true
+}
+
+// Pop removes and returns the highest-priority element.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.heap) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.removeAt(0).value, true
+}
+
+// Update replaces the element behind h with v and restores heap order.
+// Use it for decrease-key (or increase-key). It returns false if h was already removed.
+func (pq *PriorityQueue[T]) Update(h *Handle[T], v T) bool {
+	if !pq.owns(h) {
+		return false
+	}
+	h.value = v
+	pq.fix(h.index)
+	return true
+}
+
+// Remove deletes the element behind h. It returns false if h was already removed.
+func (pq *PriorityQueue[T]) Remove(h *Handle[T]) bool {
+	if !pq.owns(h) {
+		return false
+	}
+	pq.removeAt(h.index)
+	return true
+}
+
+// ----------------------
+// 2. Heap internals
+// ----------------------
+
+func (pq *PriorityQueue[T]) owns(h *Handle[T]) bool {
+	return h.index >= 0 && h.index < len(pq.heap) && pq.heap[h.index] == h
+}
+
+func (pq *PriorityQueue[T]) removeAt(i int) *Handle[T] {
+	last := len(pq.heap) - 1
+	pq.swap(i, last)
+	h := pq.heap[last]
+	pq.heap[last] = nil
+	pq.heap = pq.heap[:last]
+	if i < last {
+		pq.fix(i)
+	}
+	h.index = -1
+	return h
+}
+
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.heap[i].value, pq.heap[parent].value) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down sifts element i towards the leaves and reports whether it moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start, n := i, len(pq.heap)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if r := child + 1; r < n && pq.less(pq.heap[r].value, pq.heap[child].value) {
+			child = r
+		}
+		if !pq.less(pq.heap[child].value, pq.heap[i].value) {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+	pq.heap[i].index = i
+	pq.heap[j].index = j
+}
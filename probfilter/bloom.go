@@ -0,0 +1,137 @@
+package probfilter
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// BloomFilter is a bit array with k hash positions per item.
+// Contains never gives a false negative; false positives happen at about
+// the rate the filter was sized for, as long as it is not overfilled.
+type BloomFilter[T any] struct {
+	bits   []uint64
+	m      uint64 // number of bits
+	k      uint64 // hash positions per item
+	count  uint64 // items added
+	hasher Hasher[T]
+}
+
+// NewBloom sizes a filter for expectedItems at the target false-positive
+// rate fpRate (for example 0.01 for 1%). The bit count is rounded up to a
+// power of two, which double hashing needs (see hashes), so the filter can
+// be up to twice the minimum size, with a lower false-positive rate.
+func NewBloom[T any](expectedItems int, fpRate float64, h Hasher[T]) *BloomFilter[T] {
+	if expectedItems < 1 {
+		expectedItems = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		panic("probfilter: false-positive rate must be in (0, 1)")
+	}
+	n := float64(expectedItems)
+	m := uint64(math.Ceil(-n * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	m = 1 << bits.Len64(m-1) // round up to a power of two for double hashing
+	k := math.Max(1, math.Round(float64(m)/n*math.Ln2))
+	return newBloom(m, uint64(k), h)
+}
+
+func newBloom[T any](m, k uint64, h Hasher[T]) *BloomFilter[T] {
+	return &BloomFilter[T]{bits: make([]uint64, (m+63)/64), m: m, k: k, hasher: h}
+}
+
+// Add inserts v.
+func (f *BloomFilter[T]) Add(v T) {
+	h1, h2 := f.hashes(v)
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+	f.count++
+}
+
+// Contains reports whether v may have been added.
+// false means v was definitely never added.
+func (f *BloomFilter[T]) Contains(v T) bool {
+	h1, h2 := f.hashes(v)
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of Add calls, including repeats.
+func (f *BloomFilter[T]) Count() int { return int(f.count) }
+
+// FalsePositiveRate estimates the current false-positive rate from the
+// fraction of bits set.
+func (f *BloomFilter[T]) FalsePositiveRate() float64 {
+	set := 0
+	for _, w := range f.bits {
+		set += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.k))
+}
+
+// hashes derives the two hashes for double hashing: position i is
+// (h1 + i*h2) mod m. The positions are distinct as long as h2 and m share
+// no factor; m is a power of two, so making h2 odd is enough.
+func (f *BloomFilter[T]) hashes(v T) (uint64, uint64) {
+	h := f.hasher.Hash(v)
+	return h, mix(h) | 1
+}
+
+// ----------------------
+// Serialization
+// ----------------------
+
+var bloomMagic = [4]byte{'B', 'L', 'M', '1'}
+
+// maxHashes bounds k when decoding; NewBloom needs about 33 even for a
+// false-positive rate of 1e-10.
+const maxHashes = 64
+
+// ErrCorrupt is returned when serialized filter data cannot be decoded.
+var ErrCorrupt = errors.New("probfilter: corrupt filter data")
+
+// MarshalBinary encodes the filter as: magic, m, k, count, then the bit array,
+// all little-endian. The hasher is not included.
+func (f *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 4+3*8+8*len(f.bits))
+	out = append(out, bloomMagic[:]...)
+	out = binary.LittleEndian.AppendUint64(out, f.m)
+	out = binary.LittleEndian.AppendUint64(out, f.k)
+	out = binary.LittleEndian.AppendUint64(out, f.count)
+	for _, w := range f.bits {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// UnmarshalBloom decodes data written by MarshalBinary. h must be the same
+// (stable) hasher the filter was built with.
+func UnmarshalBloom[T any](data []byte, h Hasher[T]) (*BloomFilter[T], error) {
+	if len(data) < 28 || [4]byte(data[:4]) != bloomMagic {
+		return nil, ErrCorrupt
+	}
+	m := binary.LittleEndian.Uint64(data[4:])
+	k := binary.LittleEndian.Uint64(data[12:])
+	count := binary.LittleEndian.Uint64(data[20:])
+	words := data[28:]
+	// Check m against the payload before any arithmetic on it can overflow.
+	if m == 0 || m&(m-1) != 0 || m > uint64(len(words))*8 || uint64(len(words)) != (m+63)/64*8 {
+		return nil, ErrCorrupt
+	}
+	if k == 0 || k > maxHashes {
+		return nil, ErrCorrupt
+	}
+	f := newBloom(m, k, h)
+	f.count = count
+	for i := range f.bits {
+		f.bits[i] = binary.LittleEndian.Uint64(words[8*i:])
+	}
+	return f, nil
+}
@@ -0,0 +1,221 @@
+package probfilter
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"math/rand/v2"
+)
+
+const (
+	bucketSize = 4   // fingerprints per bucket
+	maxKicks   = 500 // relocation attempts before declaring the filter full
+	loadFactor = 0.95
+)
+
+// CuckooFilter stores a 16-bit fingerprint of every item in one of two
+// candidate buckets. Unlike a Bloom filter it supports Delete.
+// Its false-positive rate is about 8/65536 (0.012%).
+type CuckooFilter[T any] struct {
+	buckets [][bucketSize]uint16 // 0 marks an empty slot
+	count   uint64
+	hasher  Hasher[T]
+
+	// victim holds the one fingerprint left homeless by a failed insert,
+	// so that no previously added item is ever lost.
+	victim      uint16
+	victimIndex uint64
+	hasVictim   bool
+}
+
+// NewCuckoo sizes a filter to hold capacity items.
+func NewCuckoo[T any](capacity int, h Hasher[T]) *CuckooFilter[T] {
+	n := uint64(float64(max(capacity, 1))/bucketSize/loadFactor) + 1
+	n = 1 << bits.Len64(n-1) // round up to a power of two for cheap masking
+	return &CuckooFilter[T]{buckets: make([][bucketSize]uint16, n), hasher: h}
+}
+
+// Add inserts v. It returns false once the filter is full, meaning an earlier
+// insert had to park an evicted fingerprint; v is then not added, but nothing
+// added earlier is lost. Deleting items makes room again.
+func (f *CuckooFilter[T]) Add(v T) bool {
+	if f.hasVictim {
+		return false
+	}
+	i1, fp := f.indexAndFingerprint(v)
+	i2 := f.altIndex(i1, fp)
+	if f.insert(i1, fp) || f.insert(i2, fp) {
+		f.count++
+		return true
+	}
+
+	// Both buckets are full: evict fingerprints along a random walk.
+	i := i1
+	if rand.IntN(2) == 0 {
+		i = i2
+	}
+	for range maxKicks {
+		slot := rand.IntN(bucketSize)
+		fp, f.buckets[i][slot] = f.buckets[i][slot], fp
+		i = f.altIndex(i, fp)
+		if f.insert(i, fp) {
+			f.count++
+			return true
+		}
+	}
+	f.victim, f.victimIndex, f.hasVictim = fp, i, true
+	f.count++ // v itself was placed; only the evicted victim is parked
+	return true
+}
+
+// Contains reports whether v may have been added.
+func (f *CuckooFilter[T]) Contains(v T) bool {
+	i1, fp := f.indexAndFingerprint(v)
+	i2 := f.altIndex(i1, fp)
+	if f.hasVictim && f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2) {
+		return true
+	}
+	return f.find(i1, fp) >= 0 || f.find(i2, fp) >= 0
+}
+
+// Delete removes one copy of v and reports whether it was found.
+// Only delete items that were added: deleting a false positive removes
+// another item's fingerprint.
+func (f *CuckooFilter[T]) Delete(v T) bool {
+	i1, fp := f.indexAndFingerprint(v)
+	i2 := f.altIndex(i1, fp)
+	for _, i := range [2]uint64{i1, i2} {
+		if s := f.find(i, fp); s >= 0 {
+			f.buckets[i][s] = 0
+			f.count--
+			f.reinsertVictim()
+			return true
+		}
+	}
+	if f.hasVictim && f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2) {
+		f.hasVictim = false
+		f.count--
+		return true
+	}
+	return false
+}
+
+// Count returns the number of items currently stored.
+func (f *CuckooFilter[T]) Count() int { return int(f.count) }
+
+// ----------------------
+// Internals
+// ----------------------
+
+func (f *CuckooFilter[T]) indexAndFingerprint(v T) (uint64, uint16) {
+	h := f.hasher.Hash(v)
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1 // 0 means "empty slot"
+	}
+	return h & f.mask(), fp
+}
+
+// altIndex returns the other bucket for fp. It is its own inverse:
+// altIndex(altIndex(i, fp), fp) == i, so either bucket leads to the other.
+func (f *CuckooFilter[T]) altIndex(i uint64, fp uint16) uint64 {
+	return (i ^ mix(uint64(fp))) & f.mask()
+}
+
+func (f *CuckooFilter[T]) mask() uint64 { return uint64(len(f.buckets) - 1) }
+
+func (f *CuckooFilter[T]) insert(i uint64, fp uint16) bool {
+	for s, x := range f.buckets[i] {
+		if x == 0 {
+			f.buckets[i][s] = fp
+			return true
+		}
+	}
+	return false
+}
+
+func (f *CuckooFilter[T]) find(i uint64, fp uint16) int {
+	for s, x := range f.buckets[i] {
+		if x == fp {
+			return s
+		}
+	}
+	return -1
+}
+
+// reinsertVictim moves a parked fingerprint back once space has been freed.
+func (f *CuckooFilter[T]) reinsertVictim() {
+	if !f.hasVictim {
+		return
+	}
+	fp, i := f.victim, f.victimIndex
+	if f.insert(i, fp) || f.insert(f.altIndex(i, fp), fp) {
+		f.hasVictim = false
+	}
+}
+
+// ----------------------
+// Serialization
+// ----------------------
+
+var cuckooMagic = [4]byte{'C', 'K', 'O', '1'}
+
+// MarshalBinary encodes the filter as: magic, bucket count, item count,
+// victim (index, fingerprint, flag), then every slot, all little-endian.
+// The hasher is not included.
+func (f *CuckooFilter[T]) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 4+8+8+8+2+1+2*bucketSize*len(f.buckets))
+	out = append(out, cuckooMagic[:]...)
+	out = binary.LittleEndian.AppendUint64(out, uint64(len(f.buckets)))
+	out = binary.LittleEndian.AppendUint64(out, f.count)
+	out = binary.LittleEndian.AppendUint64(out, f.victimIndex)
+	out = binary.LittleEndian.AppendUint16(out, f.victim)
+	if f.hasVictim {
+		out = append(out, 1)
+	} else {
+		out = append(out, 0)
+	}
+	for _, b := range f.buckets {
+		for _, fp := range b {
+			out = binary.LittleEndian.AppendUint16(out, fp)
+		}
+	}
+	return out, nil
+}
+
+// UnmarshalCuckoo decodes data written by MarshalBinary. h must be the same
+// (stable) hasher the filter was built with.
+func UnmarshalCuckoo[T any](data []byte, h Hasher[T]) (*CuckooFilter[T], error) {
+	const header = 4 + 8 + 8 + 8 + 2 + 1
+	if len(data) < header || [4]byte(data[:4]) != cuckooMagic {
+		return nil, ErrCorrupt
+	}
+	// Derive the bucket count from the payload so nothing can overflow,
+	// then make sure the header agrees.
+	n := uint64(len(data)-header) / (2 * bucketSize)
+	if n == 0 || n&(n-1) != 0 || uint64(len(data)-header) != n*2*bucketSize ||
+		binary.LittleEndian.Uint64(data[4:]) != n {
+		return nil, ErrCorrupt
+	}
+	victimIndex := binary.LittleEndian.Uint64(data[20:])
+	victim := binary.LittleEndian.Uint16(data[28:])
+	flag := data[30]
+	if flag > 1 || (flag == 1 && (victimIndex >= n || victim == 0)) {
+		return nil, ErrCorrupt
+	}
+	f := &CuckooFilter[T]{
+		buckets:     make([][bucketSize]uint16, n),
+		count:       binary.LittleEndian.Uint64(data[12:]),
+		victimIndex: victimIndex,
+		victim:      victim,
+		hasVictim:   flag == 1,
+		hasher:      h,
+	}
+	p := data[header:]
+	for i := range f.buckets {
+		for s := range bucketSize {
+			f.buckets[i][s] = binary.LittleEndian.Uint16(p)
+			p = p[2:]
+		}
+	}
+	return f, nil
+}
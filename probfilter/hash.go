@@ -0,0 +1,66 @@
+// Package probfilter provides probabilistic set membership: a Bloom filter
+// and a cuckoo filter. Both answer "definitely not present" or "probably
+// present" using a small, fixed amount of memory per item, where a
+// map[string]bool would need to store every item in full.
+//
+// A cuckoo filter also supports deletion; a Bloom filter is simpler and
+// slightly smaller at low false-positive rates.
+package probfilter
+
+import (
+	"hash/fnv"
+	"hash/maphash"
+)
+
+// Hasher maps values of T to 64-bit hashes.
+//
+// Filters only stay valid across processes (after MarshalBinary) if the
+// hasher is stable, i.e. gives the same hash for the same value every run.
+type Hasher[T any] interface {
+	Hash(v T) uint64
+}
+
+// HasherFunc adapts an ordinary function to the Hasher interface.
+type HasherFunc[T any] func(T) uint64
+
+// Hash calls f(v).
+func (f HasherFunc[T]) Hash(v T) uint64 { return f(v) }
+
+// StringHasher returns a stable hasher for strings (FNV-1a, then mixed).
+func StringHasher() Hasher[string] {
+	return HasherFunc[string](func(s string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		return mix(h.Sum64())
+	})
+}
+
+// BytesHasher returns a stable hasher for byte slices (FNV-1a, then mixed).
+func BytesHasher() Hasher[[]byte] {
+	return HasherFunc[[]byte](func(b []byte) uint64 {
+		h := fnv.New64a()
+		h.Write(b)
+		return mix(h.Sum64())
+	})
+}
+
+// ComparableHasher returns a fast hasher for any comparable type, based on
+// hash/maphash with a random seed. It is NOT stable: a filter using it
+// cannot be serialized and reloaded in another process.
+func ComparableHasher[T comparable]() Hasher[T] {
+	seed := maphash.MakeSeed()
+	return HasherFunc[T](func(v T) uint64 {
+		return maphash.Comparable(seed, v)
+	})
+}
+
+// mix is the splitmix64 finalizer. It spreads the bits of x so that
+// derived hashes (double hashing, fingerprints) are independent enough.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,246 @@
+package probfilter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func keys(prefix string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("%s-%d", prefix, i)
+	}
+	return out
+}
+
+func TestBloomFalsePositiveRate(t *testing.T) {
+	tests := []struct {
+		n      int
+		target float64
+	}{
+		{1000, 0.1},
+		{10000, 0.01},
+		{10000, 0.001},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("n=%d/p=%g", tt.n, tt.target), func(t *testing.T) {
+			f := NewBloom(tt.n, tt.target, StringHasher())
+			for _, k := range keys("in", tt.n) {
+				f.Add(k)
+			}
+			for _, k := range keys("in", tt.n) {
+				if !f.Contains(k) {
+					t.Fatalf("false negative for %q", k)
+				}
+			}
+			probes := keys("out", 100000)
+			fp := 0
+			for _, k := range probes {
+				if f.Contains(k) {
+					fp++
+				}
+			}
+			rate := float64(fp) / float64(len(probes))
+			if rate > 1.5*tt.target {
+				t.Errorf("measured false-positive rate %g, target %g", rate, tt.target)
+			}
+			if est := f.FalsePositiveRate(); est > 1.5*tt.target {
+				t.Errorf("estimated false-positive rate %g, target %g", est, tt.target)
+			}
+		})
+	}
+}
+
+func TestBloomPositionsDistinct(t *testing.T) {
+	for _, tt := range []struct {
+		n int
+		p float64
+	}{{1, 0.5}, {100, 0.01}, {1000, 1e-6}, {3000, 0.003}} {
+		f := NewBloom(tt.n, tt.p, StringHasher())
+		if f.m&(f.m-1) != 0 {
+			t.Fatalf("NewBloom(%d, %g): m = %d, not a power of two", tt.n, tt.p, f.m)
+		}
+		for _, k := range keys("in", 1000) {
+			h1, h2 := f.hashes(k)
+			seen := map[uint64]bool{}
+			for i := range f.k {
+				seen[(h1+i*h2)%f.m] = true
+			}
+			if want := min(f.k, f.m); uint64(len(seen)) != want {
+				t.Fatalf("NewBloom(%d, %g): %q sets %d distinct bits, want %d", tt.n, tt.p, k, len(seen), want)
+			}
+		}
+	}
+}
+
+func TestCuckooFalsePositiveRate(t *testing.T) {
+	f := NewCuckoo(10000, StringHasher())
+	for _, k := range keys("in", 10000) {
+		if !f.Add(k) {
+			t.Fatalf("Add(%q) failed below capacity", k)
+		}
+	}
+	fp := 0
+	probes := keys("out", 100000)
+	for _, k := range probes {
+		if f.Contains(k) {
+			fp++
+		}
+	}
+	// 2 buckets x 4 slots, 16-bit fingerprints: about 8/65536.
+	if rate := float64(fp) / float64(len(probes)); rate > 3*8.0/65536 {
+		t.Errorf("false-positive rate %g", rate)
+	}
+}
+
+func TestBloomRoundTrip(t *testing.T) {
+	f := NewBloom(500, 0.01, StringHasher())
+	for _, k := range keys("in", 500) {
+		f.Add(k)
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := UnmarshalBloom(data, StringHasher())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Count() != f.Count() || g.m != f.m || g.k != f.k {
+		t.Fatalf("header mismatch: got m=%d k=%d count=%d", g.m, g.k, g.Count())
+	}
+	for _, k := range append(keys("in", 500), keys("out", 2000)...) {
+		if f.Contains(k) != g.Contains(k) {
+			t.Fatalf("Contains(%q) differs after round trip", k)
+		}
+	}
+}
+
+// fillToVictim adds keys until the filter has to park a fingerprint.
+func fillToVictim(t *testing.T) (*CuckooFilter[string], []string) {
+	t.Helper()
+	f := NewCuckoo(64, StringHasher())
+	var added []string
+	for _, k := range keys("in", 10000) {
+		if !f.Add(k) {
+			break
+		}
+		added = append(added, k)
+	}
+	if !f.hasVictim {
+		t.Fatal("filter never filled up")
+	}
+	return f, added
+}
+
+func TestCuckooRoundTrip(t *testing.T) {
+	f, added := fillToVictim(t)
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := UnmarshalCuckoo(data, StringHasher())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Count() != f.Count() || g.hasVictim != f.hasVictim || g.victim != f.victim || g.victimIndex != f.victimIndex {
+		t.Fatal("header mismatch after round trip")
+	}
+	for _, k := range append(added, keys("out", 2000)...) {
+		if f.Contains(k) != g.Contains(k) {
+			t.Fatalf("Contains(%q) differs after round trip", k)
+		}
+	}
+}
+
+func TestCuckooDeleteWithVictim(t *testing.T) {
+	f, added := fillToVictim(t)
+	if f.Add("one-more") {
+		t.Fatal("Add succeeded on a full filter")
+	}
+	for i, k := range added {
+		if !f.Delete(k) {
+			t.Fatalf("Delete(%q) = false for an added key", k)
+		}
+		if f.Count() != len(added)-i-1 {
+			t.Fatalf("Count = %d after %d deletes", f.Count(), i+1)
+		}
+		for _, rest := range added[i+1:] {
+			if !f.Contains(rest) {
+				t.Fatalf("lost %q after deleting %q", rest, k)
+			}
+		}
+	}
+	if f.hasVictim {
+		t.Error("victim still parked in an empty filter")
+	}
+	if !f.Add("one-more") {
+		t.Error("Add failed after deleting everything")
+	}
+}
+
+func TestUnmarshalRejectsCorruptData(t *testing.T) {
+	bloom := func(m, k uint64, payload int) []byte {
+		b := append([]byte(nil), bloomMagic[:]...)
+		b = binary.LittleEndian.AppendUint64(b, m)
+		b = binary.LittleEndian.AppendUint64(b, k)
+		b = binary.LittleEndian.AppendUint64(b, 0)
+		return append(b, make([]byte, payload)...)
+	}
+	cuckoo := func(n, victimIndex uint64, victim uint16, flag byte, buckets int) []byte {
+		b := append([]byte(nil), cuckooMagic[:]...)
+		b = binary.LittleEndian.AppendUint64(b, n)
+		b = binary.LittleEndian.AppendUint64(b, 0)
+		b = binary.LittleEndian.AppendUint64(b, victimIndex)
+		b = binary.LittleEndian.AppendUint16(b, victim)
+		b = append(b, flag)
+		return append(b, make([]byte, 2*bucketSize*buckets)...)
+	}
+
+	bloomTests := []struct {
+		name string
+		data []byte
+	}{
+		{"short", bloomMagic[:]},
+		{"bad magic", append([]byte("XXXX"), bloom(64, 3, 8)[4:]...)},
+		{"m overflows", bloom(1<<64-1, 3, 0)},
+		{"m beyond payload", bloom(65, 3, 8)},
+		{"payload too long", bloom(64, 3, 16)},
+		{"zero m", bloom(0, 3, 0)},
+		{"m not a power of two", bloom(96, 3, 16)},
+		{"zero k", bloom(64, 0, 8)},
+		{"huge k", bloom(64, 1<<40, 8)},
+	}
+	for _, tt := range bloomTests {
+		if _, err := UnmarshalBloom(tt.data, StringHasher()); !errors.Is(err, ErrCorrupt) {
+			t.Errorf("bloom %s: err = %v, want ErrCorrupt", tt.name, err)
+		}
+	}
+	if _, err := UnmarshalBloom(bloom(64, 3, 8), StringHasher()); err != nil {
+		t.Errorf("valid bloom rejected: %v", err)
+	}
+
+	cuckooTests := []struct {
+		name string
+		data []byte
+	}{
+		{"short", cuckooMagic[:]},
+		{"huge n", cuckoo(1<<61, 0, 0, 0, 2)},
+		{"n disagrees", cuckoo(4, 0, 0, 0, 2)},
+		{"not a power of two", cuckoo(3, 0, 0, 0, 3)},
+		{"no buckets", cuckoo(0, 0, 0, 0, 0)},
+		{"victim index out of range", cuckoo(2, 1<<40, 7, 1, 2)},
+		{"empty victim", cuckoo(2, 0, 0, 1, 2)},
+		{"bad flag", cuckoo(2, 0, 0, 2, 2)},
+	}
+	for _, tt := range cuckooTests {
+		if _, err := UnmarshalCuckoo(tt.data, StringHasher()); !errors.Is(err, ErrCorrupt) {
+			t.Errorf("cuckoo %s: err = %v, want ErrCorrupt", tt.name, err)
+		}
+	}
+	if _, err := UnmarshalCuckoo(cuckoo(2, 1, 7, 1, 2), StringHasher()); err != nil {
+		t.Errorf("valid cuckoo rejected: %v", err)
+	}
+}
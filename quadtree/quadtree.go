@@ -0,0 +1,371 @@
+// Package quadtree is a spatial index for positioned shapes.
+//
+// A linear scan over a []geometry.Shape tests every shape for every query.
+// A quadtree splits space into quadrants, recursively, wherever shapes are
+// crowded, so a query only visits the quadrants its area touches. Each
+// shape lives in the deepest quadrant that holds its whole bounding box.
+//
+// Shapes are usually values, so moving one means building the moved value
+// and passing it to Update with the item's handle. The tree re-files the
+// item, grows itself when something leaves its area, and merges quadrants
+// that have emptied out. It never shrinks on its own, so an item moving to
+// and fro across its edge costs no rebuilds; call Rebuild to fit the tree
+// to its items again after they have moved closer together.
+package quadtree
+
+import (
+	"geometry"
+	"iter"
+	"pqueue"
+)
+
+const (
+	maxItems = 8  // a quadrant splits when it holds more than this
+	maxDepth = 16 // ... unless it is this deep already
+)
+
+// Item is a handle to one indexed shape and the value stored with it.
+type Item[T any] struct {
+	Value T
+	shape geometry.Collider
+	box   geometry.AABB
+	node  *node[T] // nil once removed
+	index int      // position in node.items
+}
+
+// Shape returns the item's current shape.
+func (it *Item[T]) Shape() geometry.Collider { return it.shape }
+
+type node[T any] struct {
+	box    geometry.AABB
+	depth  int
+	parent *node[T]
+	kids   *[4]*node[T] // nil for a leaf
+	items  []*Item[T]
+	count  int // items in this subtree
+}
+
+// Tree indexes shapes with attached values of type T.
+type Tree[T any] struct {
+	root *node[T]
+}
+
+// New returns an empty tree covering area. Shapes outside area are still
+// accepted: the tree grows to include them.
+func New[T any](area geometry.AABB) *Tree[T] {
+	return &Tree[T]{root: &node[T]{box: area}}
+}
+
+// ----------------------
+// 1. Updates
+// ----------------------
+
+// Len returns the number of items.
+func (t *Tree[T]) Len() int { return t.root.count }
+
+// Bounds returns the area the tree currently covers.
+func (t *Tree[T]) Bounds() geometry.AABB { return t.root.box }
+
+// Insert adds shape with its value and returns the item's handle.
+func (t *Tree[T]) Insert(shape geometry.Collider, value T) *Item[T] {
+	it := &Item[T]{Value: value}
+	t.place(it, shape)
+	return it
+}
+
+// Remove deletes it from the tree. It returns false if it was already
+// removed or belongs to another tree.
+func (t *Tree[T]) Remove(it *Item[T]) bool {
+	if !t.owns(it) {
+		return false
+	}
+	t.unlink(it)
+	return true
+}
+
+// Update replaces the shape of it, typically with a moved copy, and
+// re-files it. It returns false if it is not in the tree.
+func (t *Tree[T]) Update(it *Item[T], shape geometry.Collider) bool {
+	if !t.owns(it) {
+		return false
+	}
+	box := shape.Bounds()
+	if n := it.node; n.box.ContainsBox(box) && (n.kids == nil || n.childFor(box) < 0) {
+		it.shape, it.box = shape, box // still belongs in the same quadrant
+		return true
+	}
+	t.unlink(it)
+	t.place(it, shape)
+	return true
+}
+
+// Rebuild re-creates the tree around its current items, shrinking it to
+// their bounding box. Handles stay valid.
+func (t *Tree[T]) Rebuild() {
+	items := t.collect()
+	if len(items) == 0 {
+		return
+	}
+	box := items[0].box
+	for _, it := range items[1:] {
+		box = box.Union(it.box)
+	}
+	t.rebuild(box, items)
+}
+
+// ----------------------
+// 2. Queries
+// ----------------------
+
+// Intersecting yields every item whose shape intersects area, as decided
+// by geometry.Intersects.
+func (t *Tree[T]) Intersecting(area geometry.Collider) iter.Seq[*Item[T]] {
+	box := area.Bounds()
+	return func(yield func(*Item[T]) bool) {
+		t.root.visit(box, func(it *Item[T]) bool {
+			if !geometry.Intersects(it.shape, area) {
+				return true
+			}
+			return yield(it)
+		})
+	}
+}
+
+// InRect yields every item whose shape intersects the rectangle r.
+func (t *Tree[T]) InRect(r geometry.AABB) iter.Seq[*Item[T]] {
+	rect := geometry.Rectangle{Width: r.Width(), Height: r.Height()}
+	rect.Pos = r.Center()
+	return t.Intersecting(rect)
+}
+
+// At yields every item whose shape contains p.
+func (t *Tree[T]) At(p geometry.Point) iter.Seq[*Item[T]] {
+	return func(yield func(*Item[T]) bool) {
+		t.root.visit(geometry.AABB{Min: p, Max: p}, func(it *Item[T]) bool {
+			if !it.shape.Contains(p) {
+				return true
+			}
+			return yield(it)
+		})
+	}
+}
+
+// Nearest returns up to k items ordered by the distance from p to their
+// shapes (see geometry.Distance), nearest first.
+func (t *Tree[T]) Nearest(p geometry.Point, k int) []*Item[T] {
+	// Best-first search: quadrants are queued by the distance to their box,
+	// a lower bound for everything inside, and items by their exact distance.
+	// Whatever comes off the queue first is nearer than anything left.
+	type entry struct {
+		dist float64
+		node *node[T]
+		item *Item[T]
+	}
+	pq := pqueue.New(func(a, b entry) bool { return a.dist < b.dist })
+	pq.Push(entry{dist: t.root.box.Dist(p), node: t.root})
+	var out []*Item[T]
+	for len(out) < k {
+		e, ok := pq.Pop()
+		if !ok {
+			break
+		}
+		if e.item != nil {
+			out = append(out, e.item)
+			continue
+		}
+		for _, it := range e.node.items {
+			pq.Push(entry{dist: geometry.Distance(it.shape, p), item: it})
+		}
+		if e.node.kids != nil {
+			for _, kid := range e.node.kids {
+				if kid.count > 0 {
+					pq.Push(entry{dist: kid.box.Dist(p), node: kid})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// All yields every item in no particular order.
+func (t *Tree[T]) All() iter.Seq[*Item[T]] {
+	return func(yield func(*Item[T]) bool) {
+		t.root.walk(yield)
+	}
+}
+
+// ----------------------
+// 3. Internals
+// ----------------------
+
+func (t *Tree[T]) owns(it *Item[T]) bool {
+	if it.node == nil {
+		return false
+	}
+	n := it.node
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n == t.root
+}
+
+// place files it under shape, growing the tree first if shape lies outside.
+func (t *Tree[T]) place(it *Item[T], shape geometry.Collider) {
+	it.shape, it.box = shape, shape.Bounds()
+	if !t.root.box.ContainsBox(it.box) {
+		// Grow to twice the size needed, so a shape drifting outwards does
+		// not trigger a rebuild on every step.
+		box := t.root.box.Union(it.box)
+		box = box.Expand(max(box.Width(), box.Height()) / 2)
+		t.rebuild(box, t.collect())
+	}
+	t.root.insert(it)
+}
+
+func (t *Tree[T]) rebuild(box geometry.AABB, items []*Item[T]) {
+	t.root = &node[T]{box: box}
+	for _, it := range items {
+		t.root.insert(it)
+	}
+}
+
+func (t *Tree[T]) collect() []*Item[T] {
+	items := make([]*Item[T], 0, t.root.count)
+	t.root.walk(func(it *Item[T]) bool {
+		items = append(items, it)
+		return true
+	})
+	return items
+}
+
+// unlink removes it from its quadrant and merges quadrants that became
+// sparse on the way up.
+func (t *Tree[T]) unlink(it *Item[T]) {
+	n := it.node
+	last := len(n.items) - 1
+	n.items[it.index] = n.items[last]
+	n.items[it.index].index = it.index
+	n.items[last] = nil
+	n.items = n.items[:last]
+	it.node = nil
+	for ; n != nil; n = n.parent {
+		n.count--
+		if n.kids != nil && n.count <= maxItems/2 {
+			n.merge()
+		}
+	}
+}
+
+func (n *node[T]) insert(it *Item[T]) {
+	n.count++
+	if n.kids != nil {
+		if q := n.childFor(it.box); q >= 0 {
+			n.kids[q].insert(it)
+			return
+		}
+	}
+	n.add(it)
+	if n.kids == nil && len(n.items) > maxItems && n.depth < maxDepth {
+		n.split()
+	}
+}
+
+func (n *node[T]) add(it *Item[T]) {
+	it.node, it.index = n, len(n.items)
+	n.items = append(n.items, it)
+}
+
+// childFor returns the quadrant that wholly holds box, or -1 if box
+// straddles the centre lines.
+func (n *node[T]) childFor(box geometry.AABB) int {
+	c := n.box.Center()
+	q := 0
+	switch {
+	case box.Min.X >= c.X:
+		q |= 1
+	case box.Max.X > c.X:
+		return -1
+	}
+	switch {
+	case box.Min.Y >= c.Y:
+		q |= 2
+	case box.Max.Y > c.Y:
+		return -1
+	}
+	return q
+}
+
+func (n *node[T]) split() {
+	c := n.box.Center()
+	n.kids = &[4]*node[T]{}
+	for q := range n.kids {
+		box := geometry.AABB{Min: n.box.Min, Max: c}
+		if q&1 != 0 {
+			box.Min.X, box.Max.X = c.X, n.box.Max.X
+		}
+		if q&2 != 0 {
+			box.Min.Y, box.Max.Y = c.Y, n.box.Max.Y
+		}
+		n.kids[q] = &node[T]{box: box, depth: n.depth + 1, parent: n}
+	}
+	items := n.items
+	n.items = nil
+	for _, it := range items {
+		if q := n.childFor(it.box); q >= 0 {
+			n.kids[q].count++
+			n.kids[q].add(it)
+		} else {
+			n.add(it)
+		}
+	}
+}
+
+// merge pulls every item of the subtree into n and drops its children.
+func (n *node[T]) merge() {
+	var items []*Item[T]
+	n.walk(func(it *Item[T]) bool {
+		items = append(items, it)
+		return true
+	})
+	n.kids, n.items = nil, nil
+	for _, it := range items {
+		n.add(it)
+	}
+}
+
+// visit calls f for every item in quadrants touching box, until f
+// returns false.
+func (n *node[T]) visit(box geometry.AABB, f func(*Item[T]) bool) bool {
+	if n.count == 0 || !n.box.Intersects(box) {
+		return true
+	}
+	for _, it := range n.items {
+		if it.box.Intersects(box) && !f(it) {
+			return false
+		}
+	}
+	if n.kids != nil {
+		for _, kid := range n.kids {
+			if !kid.visit(box, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (n *node[T]) walk(f func(*Item[T]) bool) bool {
+	for _, it := range n.items {
+		if !f(it) {
+			return false
+		}
+	}
+	if n.kids != nil {
+		for _, kid := range n.kids {
+			if !kid.walk(f) {
+				return false
+			}
+		}
+	}
+	return true
+}
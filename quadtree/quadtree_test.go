@@ -0,0 +1,280 @@
+package quadtree
+
+import (
+	"geometry"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func circle(x, y, r float64) geometry.Circle {
+	c := geometry.Circle{Radius: r}
+	c.Pos = geometry.Point{X: x, Y: y}
+	return c
+}
+
+func square(x, y, side float64) geometry.Square {
+	s := geometry.Square{Side: side}
+	s.Pos = geometry.Point{X: x, Y: y}
+	return s
+}
+
+func area(x0, y0, x1, y1 float64) geometry.AABB {
+	return geometry.AABB{Min: geometry.Point{X: x0, Y: y0}, Max: geometry.Point{X: x1, Y: y1}}
+}
+
+// randomShapes returns n shapes of mixed types scattered over [0, size)².
+func randomShapes(rng *rand.Rand, n int, size float64) []geometry.Collider {
+	out := make([]geometry.Collider, n)
+	for i := range out {
+		x, y, r := rng.Float64()*size, rng.Float64()*size, 0.1+rng.Float64()*size/50
+		switch i % 3 {
+		case 0:
+			out[i] = circle(x, y, r)
+		case 1:
+			out[i] = square(x, y, 2*r)
+		default:
+			tri := geometry.Triangle{Vertices: [3]geometry.Point{{X: -r, Y: -r}, {X: r, Y: -r}, {X: 0, Y: r}}}
+			tri.Pos = geometry.Point{X: x, Y: y}
+			out[i] = tri
+		}
+	}
+	return out
+}
+
+// checkTree verifies the structure of t: counts add up, every handle
+// points at its own slot, and every item sits in the deepest quadrant
+// that holds it.
+func checkTree(t *testing.T, tr *Tree[int]) {
+	t.Helper()
+	var check func(n *node[int]) int
+	check = func(n *node[int]) int {
+		count := len(n.items)
+		for i, it := range n.items {
+			if it.node != n || it.index != i {
+				t.Fatalf("item %d: stale handle", it.Value)
+			}
+			if !n.box.ContainsBox(it.box) {
+				t.Fatalf("item %d: box %v outside its quadrant %v", it.Value, it.box, n.box)
+			}
+			if n.kids != nil && n.childFor(it.box) >= 0 {
+				t.Fatalf("item %d: fits in a child quadrant", it.Value)
+			}
+		}
+		if n.kids != nil {
+			for _, kid := range n.kids {
+				if kid.parent != n || kid.depth != n.depth+1 {
+					t.Fatal("bad parent link or depth")
+				}
+				count += check(kid)
+			}
+		}
+		if count != n.count {
+			t.Fatalf("count = %d, subtree holds %d", n.count, count)
+		}
+		return count
+	}
+	if tr.root.parent != nil || tr.root.depth != 0 {
+		t.Fatal("root has a parent or a non-zero depth")
+	}
+	check(tr.root)
+}
+
+func values(seq func(func(*Item[int]) bool)) []int {
+	var out []int
+	for it := range seq {
+		out = append(out, it.Value)
+	}
+	sort.Ints(out)
+	return out
+}
+
+func TestSplitAndMerge(t *testing.T) {
+	tr := New[int](area(0, 0, 100, 100))
+	var items []*Item[int]
+	for i := range maxItems + 1 {
+		// Small squares spread over all four quadrants.
+		x, y := 10+float64(i%3)*35, 10+float64(i/3)*35
+		items = append(items, tr.Insert(square(x, y, 1), i))
+	}
+	checkTree(t, tr)
+	if tr.root.kids == nil {
+		t.Fatalf("root holding %d items did not split", tr.Len())
+	}
+	for len(items) > maxItems/2 {
+		if tr.root.kids == nil {
+			t.Fatalf("merged early at %d items", len(items))
+		}
+		if !tr.Remove(items[0]) {
+			t.Fatal("Remove = false for a present item")
+		}
+		items = items[1:]
+		checkTree(t, tr)
+	}
+	if tr.root.kids != nil {
+		t.Fatalf("root still split with %d items", tr.Len())
+	}
+	if tr.Remove(items[0]); tr.Remove(items[0]) {
+		t.Fatal("Remove = true twice")
+	}
+}
+
+func TestGrowKeepsHandles(t *testing.T) {
+	tr := New[int](area(0, 0, 10, 10))
+	a := tr.Insert(circle(5, 5, 1), 1)
+	b := tr.Insert(circle(500, -300, 2), 2)
+	checkTree(t, tr)
+	if !tr.Bounds().ContainsBox(b.box) || !tr.Bounds().ContainsBox(a.box) {
+		t.Fatalf("bounds %v do not hold both items", tr.Bounds())
+	}
+	if !tr.Update(a, circle(-900, 900, 1)) {
+		t.Fatal("Update = false for a present item")
+	}
+	checkTree(t, tr)
+	if got := values(tr.At(geometry.Point{X: -900, Y: 900})); !slices.Equal(got, []int{1}) {
+		t.Fatalf("At = %v after moving a out of bounds", got)
+	}
+	if !tr.Remove(a) || !tr.Remove(b) || tr.Len() != 0 {
+		t.Fatal("handles stopped working after the tree grew")
+	}
+
+	other := New[int](area(0, 0, 10, 10))
+	c := other.Insert(circle(1, 1, 1), 3)
+	if tr.Remove(c) || tr.Update(c, circle(2, 2, 1)) {
+		t.Fatal("tree accepted another tree's handle")
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	tr := New[int](area(0, 0, 1024, 1024))
+	var far []*Item[int]
+	for i := range 20 {
+		far = append(far, tr.Insert(circle(900+float64(i), 900, 1), i))
+	}
+	var near []*Item[int]
+	for i := range 20 {
+		near = append(near, tr.Insert(circle(10+float64(i), 10, 0.2), 100+i))
+	}
+	for _, it := range far {
+		tr.Remove(it)
+		checkTree(t, tr)
+	}
+	if got := tr.Bounds(); got != area(0, 0, 1024, 1024) {
+		t.Fatalf("bounds changed to %v without Rebuild", got)
+	}
+
+	tr.Rebuild()
+	checkTree(t, tr)
+	want := near[0].box
+	for _, it := range near[1:] {
+		want = want.Union(it.box)
+	}
+	if tr.Bounds() != want {
+		t.Fatalf("Rebuild: bounds %v, want %v", tr.Bounds(), want)
+	}
+	if got := values(tr.InRect(area(0, 0, 100, 100))); len(got) != len(near) {
+		t.Fatalf("InRect found %d of %d items", len(got), len(near))
+	}
+	for _, it := range near {
+		if !tr.Remove(it) {
+			t.Fatal("handle lost by Rebuild")
+		}
+	}
+}
+
+// TestBoundsStable moves one item to and fro across the edge of a cluster:
+// the tree grows once and then keeps its bounds.
+func TestBoundsStable(t *testing.T) {
+	tr := New[int](area(0, 0, 100, 100))
+	for i := range 200 {
+		tr.Insert(circle(float64(i%20)*5, float64(i/20)*10, 1), i)
+	}
+	it := tr.Insert(circle(50, 50, 1), -1)
+	tr.Update(it, circle(150, 50, 1))
+	grown := tr.Bounds()
+	for i := range 100 {
+		x := 50.0
+		if i%2 == 0 {
+			x = 150
+		}
+		tr.Update(it, circle(x, 50, 1))
+		if got := tr.Bounds(); got != grown {
+			t.Fatalf("update %d: bounds %v, want %v", i, got, grown)
+		}
+	}
+	checkTree(t, tr)
+}
+
+// TestQueriesMatchScan compares every query against a linear scan while
+// items are inserted, moved and removed.
+func TestQueriesMatchScan(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	tr := New[int](area(0, 0, 100, 100))
+	shapes := randomShapes(rng, 600, 120)
+	items := map[int]*Item[int]{}
+	for i, s := range shapes {
+		items[i] = tr.Insert(s, i)
+	}
+	for step := range 6 {
+		checkTree(t, tr)
+		for q := range 20 {
+			p := geometry.Point{X: rng.Float64()*140 - 10, Y: rng.Float64()*140 - 10}
+			probe := circle(p.X, p.Y, rng.Float64()*15)
+
+			var wantHit, wantAt []int
+			for i, it := range items {
+				if geometry.Intersects(it.shape, probe) {
+					wantHit = append(wantHit, i)
+				}
+				if it.shape.Contains(p) {
+					wantAt = append(wantAt, i)
+				}
+			}
+			sort.Ints(wantHit)
+			sort.Ints(wantAt)
+			if got := values(tr.Intersecting(probe)); !slices.Equal(got, wantHit) {
+				t.Fatalf("step %d query %d: Intersecting = %v, want %v", step, q, got, wantHit)
+			}
+			if got := values(tr.At(p)); !slices.Equal(got, wantAt) {
+				t.Fatalf("step %d query %d: At = %v, want %v", step, q, got, wantAt)
+			}
+
+			k := 1 + rng.IntN(10)
+			dists := make([]float64, 0, len(items))
+			for _, it := range items {
+				dists = append(dists, geometry.Distance(it.shape, p))
+			}
+			slices.Sort(dists)
+			got := tr.Nearest(p, k)
+			if len(got) != min(k, len(items)) {
+				t.Fatalf("Nearest(%v, %d) returned %d items", p, k, len(got))
+			}
+			for i, it := range got {
+				// Compare distances, not items, as ties may come in any order.
+				if d := geometry.Distance(it.shape, p); d != dists[i] {
+					t.Fatalf("Nearest(%v, %d)[%d] at distance %g, want %g", p, k, i, d, dists[i])
+				}
+			}
+		}
+
+		// Move some items, remove others.
+		for _, i := range slices.Sorted(maps.Keys(items)) {
+			it := items[i]
+			switch rng.IntN(4) {
+			case 0:
+				tr.Update(it, randomShapes(rng, 1, 150)[0])
+			case 1:
+				tr.Remove(it)
+				delete(items, i)
+			}
+		}
+		if tr.Len() != len(items) {
+			t.Fatalf("Len = %d, want %d", tr.Len(), len(items))
+		}
+	}
+	if n := len(slices.Collect(tr.All())); n != len(items) {
+		t.Fatalf("All yielded %d items, want %d", n, len(items))
+	}
+}
@@ -0,0 +1,214 @@
+// Package queue provides Stack, Queue and Deque built on a ring buffer.
+//
+// The APPEND REALLOCATION lesson in Maps.go shows the problem with popping by
+// reslicing (s = s[1:]): the dropped elements stay reachable in the backing
+// array, and other slices may alias it. A ring buffer reuses its slots, clears
+// popped ones, and gives amortized O(1) push and pop at both ends.
+package queue
+
+import "iter"
+
+// Mode decides what happens when a buffer reaches its capacity.
+type Mode int
+
+const (
+	// Grow doubles the buffer when full (and shrinks it when mostly empty).
+	Grow Mode = iota
+	// Overwrite keeps the capacity fixed and drops the element at the
+	// opposite end to make room, like a log that keeps the last N entries.
+	Overwrite
+	// Reject keeps the capacity fixed and refuses the push.
+	Reject
+)
+
+const minCapacity = 8
+
+// Deque is a double-ended queue. The zero value is an empty, growable deque.
+type Deque[T any] struct {
+	buf  []T
+	head int // index of the front element in buf
+	n    int // number of elements
+	mode Mode
+}
+
+// NewDeque returns a deque with room for capacity elements.
+// With Grow the capacity is only a starting size; with Overwrite and Reject
+// it is a hard limit and must be positive.
+func NewDeque[T any](capacity int, mode Mode) *Deque[T] {
+	if mode != Grow && capacity < 1 {
+		panic("queue: fixed capacity must be positive")
+	}
+	return &Deque[T]{buf: make([]T, max(capacity, 0)), mode: mode}
+}
+
+// ----------------------
+// 1. Size
+// ----------------------
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() int { return d.n }
+
+// Cap returns the number of slots in the buffer.
+func (d *Deque[T]) Cap() int { return len(d.buf) }
+
+// Full reports whether the next push will grow, overwrite or be rejected.
+func (d *Deque[T]) Full() bool { return d.n == len(d.buf) }
+
+// Clear removes every element, keeping the buffer.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head, d.n = 0, 0
+}
+
+// ----------------------
+// 2. Push and pop
+// ----------------------
+
+// PushBack adds v at the back. It returns false if the deque is full in
+// Reject mode; in Overwrite mode a full deque drops its front element.
+func (d *Deque[T]) PushBack(v T) bool {
+	if d.Full() {
+		switch d.mode {
+		case Reject:
+			return false
+		case Overwrite:
+			d.PopFront()
+		default:
+			d.resize(max(2*len(d.buf), minCapacity))
+		}
+	}
+	d.buf[d.index(d.n)] = v
+	d.n++
+	return true
+}
+
+// PushFront adds v at the front. It returns false if the deque is full in
+// Reject mode; in Overwrite mode a full deque drops its back element.
+func (d *Deque[T]) PushFront(v T) bool {
+	if d.Full() {
+		switch d.mode {
+		case Reject:
+			return false
+		case Overwrite:
+			d.PopBack()
+		default:
+			d.resize(max(2*len(d.buf), minCapacity))
+		}
+	}
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+	return true
+}
+
+// PopFront removes and returns the front element.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // let the garbage collector reclaim it
+	d.head = d.index(1)
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes and returns the back element.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := d.index(d.n - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// ----------------------
+// 3. Access and iteration
+// ----------------------
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.n-1)], true
+}
+
+// At returns the i-th element counting from the front. It panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("queue: index out of range")
+	}
+	return d.buf[d.index(i)]
+}
+
+// All iterates from front to back, yielding logical positions.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates from back to front, yielding logical positions.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.n - 1; i >= 0; i-- {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// ----------------------
+// 4. Ring buffer internals
+// ----------------------
+
+// index maps a logical offset from the front to a slot in buf.
+func (d *Deque[T]) index(offset int) int {
+	return (d.head + offset) % len(d.buf)
+}
+
+// resize copies the elements, in logical order, into a buffer of size n.
+func (d *Deque[T]) resize(n int) {
+	buf := make([]T, n)
+	if d.n > 0 {
+		end := d.head + d.n
+		if end <= len(d.buf) {
+			copy(buf, d.buf[d.head:end])
+		} else {
+			k := copy(buf, d.buf[d.head:])
+			copy(buf[k:], d.buf[:end-len(d.buf)])
+		}
+	}
+	d.buf, d.head = buf, 0
+}
+
+// shrink halves a growable buffer once it is a quarter full,
+// so a deque that once held many elements does not pin that memory forever.
+func (d *Deque[T]) shrink() {
+	if d.mode == Grow && len(d.buf) > minCapacity && d.n <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
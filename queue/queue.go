@@ -0,0 +1,79 @@
+package queue
+
+import "iter"
+
+// ----------------------
+// 1. Stack (LIFO)
+// ----------------------
+
+// Stack is a last-in, first-out collection. The zero value is an empty, growable stack.
+type Stack[T any] struct {
+	d Deque[T]
+}
+
+// NewStack returns a stack with the given capacity and full-buffer mode.
+// In Overwrite mode a full stack drops its bottom (oldest) element.
+func NewStack[T any](capacity int, mode Mode) *Stack[T] {
+	return &Stack[T]{d: *NewDeque[T](capacity, mode)}
+}
+
+// Push adds v on top. It returns false only when a Reject-mode stack is full.
+func (s *Stack[T]) Push(v T) bool { return s.d.PushBack(v) }
+
+// Pop removes and returns the top element.
+func (s *Stack[T]) Pop() (T, bool) { return s.d.PopBack() }
+
+// Peek returns the top element without removing it.
+func (s *Stack[T]) Peek() (T, bool) { return s.d.Back() }
+
+// Len returns the number of elements.
+func (s *Stack[T]) Len() int { return s.d.Len() }
+
+// All iterates from the top of the stack to the bottom, i.e. in pop order.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.d.Backward() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ----------------------
+// 2. Queue (FIFO)
+// ----------------------
+
+// Queue is a first-in, first-out collection. The zero value is an empty, growable queue.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// NewQueue returns a queue with the given capacity and full-buffer mode.
+// In Overwrite mode a full queue drops its oldest element.
+func NewQueue[T any](capacity int, mode Mode) *Queue[T] {
+	return &Queue[T]{d: *NewDeque[T](capacity, mode)}
+}
+
+// Enqueue adds v at the back. It returns false only when a Reject-mode queue is full.
+func (q *Queue[T]) Enqueue(v T) bool { return q.d.PushBack(v) }
+
+// Dequeue removes and returns the front element.
+func (q *Queue[T]) Dequeue() (T, bool) { return q.d.PopFront() }
+
+// Peek returns the front element without removing it.
+func (q *Queue[T]) Peek() (T, bool) { return q.d.Front() }
+
+// Len returns the number of elements.
+func (q *Queue[T]) Len() int { return q.d.Len() }
+
+// All iterates from front to back, i.e. in dequeue order.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range q.d.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Package rangequery answers aggregate queries over index ranges of a slice
+// while the slice keeps changing.
+//
+// Recomputing sum(s[lo:hi]) with a loop is O(n) per query. A Fenwick tree
+// does prefix sums and point updates in O(log n); a segment tree does the
+// same for any associative combine (min, max, gcd, ...) and can also apply
+// an update to a whole range in O(log n) by deferring it ("lazy
+// propagation") until a query needs it.
+//
+// All ranges are half-open, like slice expressions: [lo, hi).
+package rangequery
+
+// Fenwick is a binary indexed tree over ints.
+type Fenwick struct {
+	tree []int // 1-based: tree[i] holds the sum of the lowbit(i) values ending at i
+}
+
+// NewFenwick builds a tree holding a copy of values in O(n).
+func NewFenwick(values []int) *Fenwick {
+	t := make([]int, len(values)+1)
+	copy(t[1:], values)
+	for i := 1; i < len(t); i++ {
+		if j := i + i&-i; j < len(t) {
+			t[j] += t[i]
+		}
+	}
+	return &Fenwick{tree: t}
+}
+
+// Len returns the number of values.
+func (f *Fenwick) Len() int { return len(f.tree) - 1 }
+
+// Add adds delta to value i.
+func (f *Fenwick) Add(i, delta int) {
+	f.check(i)
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// Set replaces value i with v.
+func (f *Fenwick) Set(i, v int) {
+	f.Add(i, v-f.Get(i))
+}
+
+// Get returns value i.
+func (f *Fenwick) Get(i int) int {
+	f.check(i)
+	return f.Sum(i, i+1)
+}
+
+// PrefixSum returns the sum of values [0, n).
+func (f *Fenwick) PrefixSum(n int) int {
+	if n < 0 || n > f.Len() {
+		panic("rangequery: index out of range")
+	}
+	sum := 0
+	for ; n > 0; n -= n & -n {
+		sum += f.tree[n]
+	}
+	return sum
+}
+
+// Sum returns the sum of values [lo, hi).
+func (f *Fenwick) Sum(lo, hi int) int {
+	if lo > hi {
+		panic("rangequery: invalid range")
+	}
+	return f.PrefixSum(hi) - f.PrefixSum(lo)
+}
+
+func (f *Fenwick) check(i int) {
+	if i < 0 || i >= f.Len() {
+		panic("rangequery: index out of range")
+	}
+}
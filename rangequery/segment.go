@@ -0,0 +1,228 @@
+package rangequery
+
+import "math"
+
+// Ops describes the aggregate a SegmentTree maintains and the range updates
+// it accepts.
+//
+// Combine must be associative, with Identity as its neutral element (0 for
+// sums, math.MaxInt for minimums). Apply and Compose are only needed for
+// Update and may be left nil otherwise.
+type Ops[T, U any] struct {
+	Combine  func(a, b T) T
+	Identity T
+
+	// Apply returns the aggregate of n values, previously agg, after u has
+	// been applied to each of them.
+	Apply func(agg T, u U, n int) T
+
+	// Compose merges two pending updates: applying first and then second
+	// must equal applying Compose(first, second) once.
+	Compose func(first, second U) U
+}
+
+// SegmentTree keeps the aggregate of every power-of-two-aligned range of a
+// slice of T, plus pending updates of type U for ranges not yet visited.
+type SegmentTree[T, U any] struct {
+	n       int
+	agg     []T
+	lazy    []U
+	pending []bool
+	ops     Ops[T, U]
+}
+
+// NewSegmentTree builds a tree over a copy of values in O(n).
+func NewSegmentTree[T, U any](values []T, ops Ops[T, U]) *SegmentTree[T, U] {
+	size := 1
+	for size < len(values) {
+		size *= 2
+	}
+	t := &SegmentTree[T, U]{
+		n:       len(values),
+		agg:     make([]T, 2*size),
+		lazy:    make([]U, 2*size),
+		pending: make([]bool, 2*size),
+		ops:     ops,
+	}
+	if len(values) > 0 {
+		t.build(1, 0, len(values), values)
+	}
+	return t
+}
+
+// NewSumTree returns a tree answering range sums, where Update adds a
+// value to every element in the range.
+func NewSumTree(values []int) *SegmentTree[int, int] {
+	return NewSegmentTree(values, Ops[int, int]{
+		Combine:  func(a, b int) int { return a + b },
+		Identity: 0,
+		Apply:    func(agg, u, n int) int { return agg + u*n },
+		Compose:  func(a, b int) int { return a + b },
+	})
+}
+
+// NewMinTree returns a tree answering range minimums, where Update adds a
+// value to every element in the range. An empty range yields math.MaxInt.
+func NewMinTree(values []int) *SegmentTree[int, int] {
+	return NewSegmentTree(values, Ops[int, int]{
+		Combine:  func(a, b int) int { return min(a, b) },
+		Identity: math.MaxInt,
+		Apply:    func(agg, u, _ int) int { return agg + u },
+		Compose:  func(a, b int) int { return a + b },
+	})
+}
+
+// NewMaxTree returns a tree answering range maximums, where Update adds a
+// value to every element in the range. An empty range yields math.MinInt.
+func NewMaxTree(values []int) *SegmentTree[int, int] {
+	return NewSegmentTree(values, Ops[int, int]{
+		Combine:  func(a, b int) int { return max(a, b) },
+		Identity: math.MinInt,
+		Apply:    func(agg, u, _ int) int { return agg + u },
+		Compose:  func(a, b int) int { return a + b },
+	})
+}
+
+// ----------------------
+// 1. Queries and updates
+// ----------------------
+
+// Len returns the number of values.
+func (t *SegmentTree[T, U]) Len() int { return t.n }
+
+// Query returns the aggregate of values [lo, hi), or Identity if the range
+// is empty.
+func (t *SegmentTree[T, U]) Query(lo, hi int) T {
+	t.checkRange(lo, hi)
+	if lo == hi {
+		return t.ops.Identity
+	}
+	return t.query(1, 0, t.n, lo, hi)
+}
+
+// Get returns value i.
+func (t *SegmentTree[T, U]) Get(i int) T {
+	t.checkRange(i, i+1)
+	return t.query(1, 0, t.n, i, i+1)
+}
+
+// Set replaces value i with v.
+func (t *SegmentTree[T, U]) Set(i int, v T) {
+	t.checkRange(i, i+1)
+	t.set(1, 0, t.n, i, v)
+}
+
+// Update applies u to every value in [lo, hi). It panics if the tree was
+// built without Apply and Compose.
+func (t *SegmentTree[T, U]) Update(lo, hi int, u U) {
+	t.checkRange(lo, hi)
+	if t.ops.Apply == nil || t.ops.Compose == nil {
+		panic("rangequery: Update needs Ops.Apply and Ops.Compose")
+	}
+	if lo < hi {
+		t.update(1, 0, t.n, lo, hi, u)
+	}
+}
+
+// Values copies the current values into a new slice.
+func (t *SegmentTree[T, U]) Values() []T {
+	out := make([]T, t.n)
+	for i := range out {
+		out[i] = t.Get(i)
+	}
+	return out
+}
+
+// ----------------------
+// 2. Internals
+// ----------------------
+
+// Node x covers [l, r); its children are 2x over [l, mid) and 2x+1 over
+// [mid, r).
+
+func (t *SegmentTree[T, U]) build(x, l, r int, values []T) {
+	if r-l == 1 {
+		t.agg[x] = values[l]
+		return
+	}
+	mid := (l + r) / 2
+	t.build(2*x, l, mid, values)
+	t.build(2*x+1, mid, r, values)
+	t.agg[x] = t.ops.Combine(t.agg[2*x], t.agg[2*x+1])
+}
+
+// applyNode applies u to all of node x and records it for x's children.
+func (t *SegmentTree[T, U]) applyNode(x, l, r int, u U) {
+	t.agg[x] = t.ops.Apply(t.agg[x], u, r-l)
+	if r-l == 1 {
+		return
+	}
+	if t.pending[x] {
+		t.lazy[x] = t.ops.Compose(t.lazy[x], u)
+	} else {
+		t.lazy[x], t.pending[x] = u, true
+	}
+}
+
+// push hands node x's pending update down to its children.
+func (t *SegmentTree[T, U]) push(x, l, r int) {
+	if !t.pending[x] {
+		return
+	}
+	mid := (l + r) / 2
+	t.applyNode(2*x, l, mid, t.lazy[x])
+	t.applyNode(2*x+1, mid, r, t.lazy[x])
+	var zero U
+	t.lazy[x], t.pending[x] = zero, false
+}
+
+func (t *SegmentTree[T, U]) query(x, l, r, lo, hi int) T {
+	if lo <= l && r <= hi {
+		return t.agg[x]
+	}
+	t.push(x, l, r)
+	mid := (l + r) / 2
+	switch {
+	case hi <= mid:
+		return t.query(2*x, l, mid, lo, hi)
+	case lo >= mid:
+		return t.query(2*x+1, mid, r, lo, hi)
+	}
+	return t.ops.Combine(t.query(2*x, l, mid, lo, hi), t.query(2*x+1, mid, r, lo, hi))
+}
+
+func (t *SegmentTree[T, U]) set(x, l, r, i int, v T) {
+	if r-l == 1 {
+		t.agg[x] = v
+		return
+	}
+	t.push(x, l, r)
+	mid := (l + r) / 2
+	if i < mid {
+		t.set(2*x, l, mid, i, v)
+	} else {
+		t.set(2*x+1, mid, r, i, v)
+	}
+	t.agg[x] = t.ops.Combine(t.agg[2*x], t.agg[2*x+1])
+}
+
+func (t *SegmentTree[T, U]) update(x, l, r, lo, hi int, u U) {
+	if hi <= l || r <= lo {
+		return
+	}
+	if lo <= l && r <= hi {
+		t.applyNode(x, l, r, u)
+		return
+	}
+	t.push(x, l, r)
+	mid := (l + r) / 2
+	t.update(2*x, l, mid, lo, hi, u)
+	t.update(2*x+1, mid, r, lo, hi, u)
+	t.agg[x] = t.ops.Combine(t.agg[2*x], t.agg[2*x+1])
+}
+
+func (t *SegmentTree[T, U]) checkRange(lo, hi int) {
+	if lo < 0 || hi > t.n || lo > hi {
+		panic("rangequery: index out of range")
+	}
+}
@@ -0,0 +1,212 @@
+// Package set provides a generic Set type to replace the map[string]bool
+// pattern from Maps.go.
+//
+// The zero value is an empty set ready to use. Operations that combine sets
+// return a new set and leave their operands unchanged.
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Set is an unordered collection of distinct values.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// New returns a set holding items.
+func New[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// ----------------------
+// 1. Basic operations
+// ----------------------
+
+// Add inserts items; duplicates are ignored.
+func (s *Set[T]) Add(items ...T) {
+	if s.m == nil {
+		s.m = make(map[T]struct{}, len(items))
+	}
+	for _, v := range items {
+		s.m[v] = struct{}{}
+	}
+}
+
+// Remove deletes items; missing ones are ignored.
+func (s *Set[T]) Remove(items ...T) {
+	for _, v := range items {
+		delete(s.m, v)
+	}
+}
+
+// Contains reports whether v is in the set.
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Len returns the number of elements.
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// Clone returns an independent copy of s.
+func (s *Set[T]) Clone() *Set[T] {
+	c := &Set[T]{m: make(map[T]struct{}, len(s.m))}
+	for v := range s.m {
+		c.m[v] = struct{}{}
+	}
+	return c
+}
+
+// All iterates over the elements in unspecified order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Items returns the elements as a slice in unspecified order.
+func (s *Set[T]) Items() []T {
+	out := make([]T, 0, len(s.m))
+	for v := range s.m {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Sorted iterates over the elements of an ordered set in ascending order.
+func Sorted[T cmp.Ordered](s *Set[T]) iter.Seq[T] {
+	return slices.Values(slices.Sorted(s.All()))
+}
+
+// String formats the set like set[a b c].
+func (s *Set[T]) String() string {
+	parts := make([]string, 0, len(s.m))
+	for v := range s.m {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	slices.Sort(parts) // stable output for printing and comparison
+	return "set[" + strings.Join(parts, " ") + "]"
+}
+
+// ----------------------
+// 2. Set algebra
+// ----------------------
+
+// Union returns the elements in s or other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	out := s.Clone()
+	for v := range other.m {
+		out.m[v] = struct{}{}
+	}
+	return out
+}
+
+// Intersection returns the elements in both s and other.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	out := &Set[T]{m: make(map[T]struct{})}
+	for v := range small.m {
+		if large.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference returns the elements in s but not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	out := &Set[T]{m: make(map[T]struct{})}
+	for v := range s.m {
+		if !other.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns the elements in exactly one of s and other.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	out := s.Difference(other)
+	for v := range other.m {
+		if !s.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// IsSubset reports whether every element of s is in other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for v := range s.m {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether s contains every element of other.
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal reports whether s and other hold the same elements.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// ----------------------
+// 3. JSON
+// ----------------------
+
+// MarshalJSON encodes the set as a JSON array. Elements are sorted by their
+// encoded form so the output is deterministic. The receiver is a value so
+// that a Set stored by value, say in a struct field, encodes the same way.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	encoded := make([][]byte, 0, len(s.m))
+	for v := range s.m {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, b)
+	}
+	slices.SortFunc(encoded, bytes.Compare)
+	return append(append([]byte{'['}, bytes.Join(encoded, []byte{','})...), ']'), nil
+}
+
+// UnmarshalJSON decodes a JSON array, dropping duplicates, in place of the
+// current elements. As with encoding/json, null is a no-op.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if items == nil {
+		return nil // null
+	}
+	s.m = make(map[T]struct{}, len(items))
+	s.Add(items...)
+	return nil
+}
@@ -0,0 +1,58 @@
+package set
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []int
+		wantErr bool
+	}{
+		{"array", `[3, 1, 3, 2]`, []int{1, 2, 3}, false},
+		{"empty array", `[]`, nil, false},
+		{"null keeps the set", `null`, []int{7, 8}, false},
+		{"not an array", `{"a": 1}`, []int{7, 8}, true},
+		{"wrong element type", `["x"]`, []int{7, 8}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(7, 8)
+			err := json.Unmarshal([]byte(tt.data), s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := slices.Sorted(s.All()); !slices.Equal(got, tt.want) {
+				t.Fatalf("set holds %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A null field leaves a zero Set usable.
+	var v struct{ S Set[string] }
+	if err := json.Unmarshal([]byte(`{"S": null}`), &v); err != nil || v.S.Len() != 0 {
+		t.Fatalf("null field: %v, %v", v.S.Items(), err)
+	}
+	v.S.Add("a")
+}
+
+func TestMarshalJSON(t *testing.T) {
+	var v struct {
+		S Set[int]
+		P *Set[string]
+		N *Set[string]
+	}
+	v.S.Add(3, 1, 2)
+	v.P = New("b", "a")
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"S":[1,2,3],"P":["a","b"],"N":null}`; string(data) != want {
+		t.Fatalf("encoded %s, want %s", data, want)
+	}
+}
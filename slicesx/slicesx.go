@@ -0,0 +1,162 @@
+// Package slicesx is a functional toolkit for slices, picking up where
+// PrintSlice and Swap in Generics.go stop.
+//
+// Memory behaviour is stated on every function:
+//   - "Allocates" means the result has its own backing array; changing it
+//     never changes the input (see SHARED BACKING ARRAY in Maps.go).
+//   - "Reuses" means the result shares the input's backing array.
+//   - "No allocation" means nothing is returned that could alias.
+package slicesx
+
+import "tuple"
+
+// ----------------------
+// 1. Transforming
+// ----------------------
+
+// Map applies f to every element.
+// Allocates a result of len(s).
+func Map[T, U any](s []T, f func(T) U) []U {
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// FlatMap applies f to every element and concatenates the results.
+// Allocates.
+func FlatMap[T, U any](s []T, f func(T) []U) []U {
+	var out []U
+	for _, v := range s {
+		out = append(out, f(v)...)
+	}
+	return out
+}
+
+// Reduce folds s from left to right, starting from init.
+// No allocation.
+func Reduce[T, A any](s []T, init A, f func(A, T) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// ----------------------
+// 2. Selecting
+// ----------------------
+
+// Filter returns the elements for which keep returns true.
+// Allocates; s is left untouched.
+func Filter[T any](s []T, keep func(T) bool) []T {
+	var out []T
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// FilterInPlace is Filter without the allocation.
+// Reuses s: kept elements are moved to the front and the tail is zeroed,
+// so s must not be used afterwards except through the returned slice.
+func FilterInPlace[T any](s []T, keep func(T) bool) []T {
+	n := 0
+	for _, v := range s {
+		if keep(v) {
+			s[n] = v
+			n++
+		}
+	}
+	clear(s[n:]) // drop references so the garbage collector can free them
+	return s[:n]
+}
+
+// Partition splits s into the elements that match and those that do not,
+// keeping their relative order.
+// Allocates two slices.
+func Partition[T any](s []T, match func(T) bool) (yes, no []T) {
+	for _, v := range s {
+		if match(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// Distinct returns s without duplicates, keeping the first occurrence.
+// Allocates.
+func Distinct[T comparable](s []T) []T {
+	seen := make(map[T]struct{}, len(s))
+	var out []T
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// ----------------------
+// 3. Grouping and splitting
+// ----------------------
+
+// GroupBy buckets elements by key, like the groups map in Maps.go.
+// Order inside each bucket follows s.
+// Allocates.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Chunk splits s into consecutive pieces of size n; the last may be shorter.
+// Reuses s: each chunk is a view into it. Chunks are capacity-limited, so
+// appending to one reallocates instead of overwriting the next chunk.
+// Panics if n < 1.
+func Chunk[T any](s []T, n int) [][]T {
+	if n < 1 {
+		panic("slicesx: chunk size must be positive")
+	}
+	chunks := make([][]T, 0, (len(s)+n-1)/n)
+	for i := 0; i < len(s); i += n {
+		end := min(i+n, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
+// ----------------------
+// 4. Zipping
+// ----------------------
+
+// Zip pairs a[i] with b[i]; the result is as long as the shorter input.
+// Allocates.
+func Zip[A, B any](a []A, b []B) []tuple.Pair[A, B] {
+	n := min(len(a), len(b))
+	out := make([]tuple.Pair[A, B], n)
+	for i := range n {
+		out[i] = tuple.NewPair(a[i], b[i])
+	}
+	return out
+}
+
+// Unzip is the inverse of Zip.
+// Allocates two slices.
+func Unzip[A, B any](pairs []tuple.Pair[A, B]) ([]A, []B) {
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.First, p.Second
+	}
+	return as, bs
+}
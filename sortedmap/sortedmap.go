@@ -0,0 +1,271 @@
+// Package sortedmap provides a map kept in key order, implemented as an
+// indexable skip list.
+//
+// Lookups, inserts and deletes are O(log n) on average. Every forward link
+// also records how many entries it jumps over (its "span"), which is what
+// makes Rank and Select O(log n) as well.
+package sortedmap
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+)
+
+const (
+	maxLevel = 32
+	// Each level up is taken with probability 1/branching.
+	branching = 4
+)
+
+type node[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	next  []*node[K, V]
+	span  []int // span[i] = entries skipped by following next[i], counting the target
+}
+
+// SortedMap is an ordered map from K to V.
+type SortedMap[K cmp.Ordered, V any] struct {
+	head   *node[K, V]
+	level  int // levels currently in use, at least 1
+	length int
+	rng    *rand.Rand
+}
+
+// New returns an empty map with a randomly seeded level generator.
+func New[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return NewSeeded[K, V](rand.Uint64())
+}
+
+// NewSeeded returns an empty map whose level generator is seeded with seed.
+// The same seed and the same sequence of operations always build the same
+// list, which makes tests and benchmarks reproducible.
+func NewSeeded[K cmp.Ordered, V any](seed uint64) *SortedMap[K, V] {
+	return &SortedMap[K, V]{
+		head:  &node[K, V]{next: make([]*node[K, V], maxLevel), span: make([]int, maxLevel)},
+		level: 1,
+		rng:   rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// ----------------------
+// 1. Map operations
+// ----------------------
+
+// Len returns the number of entries.
+func (m *SortedMap[K, V]) Len() int { return m.length }
+
+// Get returns the value for key.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	if n := m.lowerBound(key); n != nil && n.key == key {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores value under key.
+func (m *SortedMap[K, V]) Set(key K, value V) {
+	var update [maxLevel]*node[K, V]
+	var rank [maxLevel]int // position of update[i], counted from the head
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		if i < m.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && x.next[i].key < key {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	if n := x.next[0]; n != nil && n.key == key {
+		n.value = value
+		return
+	}
+
+	lvl := m.randomLevel()
+	if lvl > m.level {
+		for i := m.level; i < lvl; i++ {
+			update[i] = m.head
+			m.head.span[i] = m.length
+		}
+		m.level = lvl
+	}
+	n := &node[K, V]{key: key, value: value, next: make([]*node[K, V], lvl), span: make([]int, lvl)}
+	for i := 0; i < lvl; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+		n.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := lvl; i < m.level; i++ {
+		update[i].span[i]++ // links above the new node now jump over one more entry
+	}
+	m.length++
+}
+
+// Delete removes key and reports whether it was present.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	var update [maxLevel]*node[K, V]
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	x = x.next[0]
+	if x == nil || x.key != key {
+		return false
+	}
+	for i := 0; i < m.level; i++ {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.length--
+	return true
+}
+
+// ----------------------
+// 2. Ordered lookups
+// ----------------------
+
+// Min returns the smallest key.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	return entryOf(m.head.next[0])
+}
+
+// Max returns the largest key.
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	if x == m.head {
+		return entryOf[K, V](nil)
+	}
+	return entryOf(x)
+}
+
+// Floor returns the greatest key <= key.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key <= key {
+			x = x.next[i]
+		}
+	}
+	if x == m.head {
+		return entryOf[K, V](nil)
+	}
+	return entryOf(x)
+}
+
+// Ceiling returns the least key >= key.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(m.lowerBound(key))
+}
+
+// Rank returns the number of keys less than key, which is the index key has
+// (or would have) in ascending order.
+func (m *SortedMap[K, V]) Rank(key K) int {
+	rank := 0
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+	}
+	return rank
+}
+
+// Select returns the entry at index i in ascending order (0-based).
+func (m *SortedMap[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= m.length {
+		return entryOf[K, V](nil)
+	}
+	target, traversed := i+1, 0
+	x := m.head
+	for l := m.level - 1; l >= 0; l-- {
+		for x.next[l] != nil && traversed+x.span[l] <= target {
+			traversed += x.span[l]
+			x = x.next[l]
+		}
+		if traversed == target {
+			break
+		}
+	}
+	return entryOf(x)
+}
+
+// ----------------------
+// 3. Iteration
+// ----------------------
+
+// All iterates over every entry in ascending key order.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return m.from(m.head.next[0], nil)
+}
+
+// Range iterates over the entries with lo <= key < hi in ascending order.
+func (m *SortedMap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return m.from(m.lowerBound(lo), func(k K) bool { return k < hi })
+}
+
+// from yields entries starting at n while inRange (if set) holds.
+func (m *SortedMap[K, V]) from(n *node[K, V], inRange func(K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for ; n != nil; n = n.next[0] {
+			if inRange != nil && !inRange(n.key) {
+				return
+			}
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// ----------------------
+// 4. Internals
+// ----------------------
+
+// lowerBound returns the first node with key >= key, or nil.
+func (m *SortedMap[K, V]) lowerBound(key K) *node[K, V] {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+	}
+	return x.next[0]
+}
+
+func (m *SortedMap[K, V]) randomLevel() int {
+	lvl := 1
+	for lvl < maxLevel && m.rng.IntN(branching) == 0 {
+		lvl++
+	}
+	return lvl
+}
+
+func entryOf[K cmp.Ordered, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.key, n.value, true
+}
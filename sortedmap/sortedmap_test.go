@@ -0,0 +1,114 @@
+package sortedmap
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// check compares every order query on m against ref, the sorted keys m
+// should hold. Each key k maps to the value k*10.
+func check(t *testing.T, m *SortedMap[int, int], ref []int) {
+	t.Helper()
+	if m.Len() != len(ref) {
+		t.Fatalf("Len = %d, want %d", m.Len(), len(ref))
+	}
+	if got := slices.Collect(keysOf(m.All())); !slices.Equal(got, ref) {
+		t.Fatalf("All = %v, want %v", got, ref)
+	}
+	// Probe every key, every gap between keys and both ends.
+	lo, hi := -2, 2
+	if len(ref) > 0 {
+		lo, hi = ref[0]-2, ref[len(ref)-1]+2
+	}
+	for q := lo; q <= hi; q++ {
+		i := sort.SearchInts(ref, q) // index of the first key >= q
+		if got := m.Rank(q); got != i {
+			t.Fatalf("Rank(%d) = %d, want %d", q, got, i)
+		}
+		k, v, ok := m.Ceiling(q)
+		if want := i < len(ref); ok != want || ok && (k != ref[i] || v != k*10) {
+			t.Fatalf("Ceiling(%d) = %d, %d, %v", q, k, v, ok)
+		}
+		j := i - 1 // index of the last key <= q
+		if i < len(ref) && ref[i] == q {
+			j = i
+		}
+		k, v, ok = m.Floor(q)
+		if want := j >= 0; ok != want || ok && (k != ref[j] || v != k*10) {
+			t.Fatalf("Floor(%d) = %d, %d, %v", q, k, v, ok)
+		}
+		for _, width := range []int{0, 1, 7} {
+			got := slices.Collect(keysOf(m.Range(q, q+width)))
+			want := ref[i:sort.SearchInts(ref, q+width)]
+			if !slices.Equal(got, want) {
+				t.Fatalf("Range(%d, %d) = %v, want %v", q, q+width, got, want)
+			}
+		}
+	}
+	for i := -1; i <= len(ref); i++ {
+		k, v, ok := m.Select(i)
+		if want := i >= 0 && i < len(ref); ok != want || ok && (k != ref[i] || v != k*10) {
+			t.Fatalf("Select(%d) = %d, %d, %v", i, k, v, ok)
+		}
+	}
+}
+
+func keysOf(seq iter.Seq2[int, int]) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func TestOrderQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int // keys inserted
+		span    int // keys drawn from [0, span)
+		deleted int // keys deleted afterwards
+	}{
+		{"empty", 0, 10, 0},
+		{"one", 1, 10, 0},
+		{"dense", 200, 200, 0},
+		{"sparse", 200, 5000, 0},
+		{"duplicates", 500, 100, 0},
+		{"after deletes", 300, 1000, 150},
+		{"delete all", 100, 1000, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			m := NewSeeded[int, int](42)
+			set := map[int]bool{}
+			for range tt.n {
+				k := rng.IntN(tt.span)
+				m.Set(k, k*10)
+				set[k] = true
+			}
+			ref := make([]int, 0, len(set))
+			for k := range set {
+				ref = append(ref, k)
+			}
+			sort.Ints(ref)
+			check(t, m, ref)
+
+			for range min(tt.deleted, len(ref)) {
+				i := rng.IntN(len(ref))
+				if !m.Delete(ref[i]) {
+					t.Fatalf("Delete(%d) = false for a present key", ref[i])
+				}
+				if m.Delete(ref[i]) {
+					t.Fatalf("Delete(%d) = true twice", ref[i])
+				}
+				ref = slices.Delete(ref, i, i+1)
+			}
+			check(t, m, ref)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package table
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// ----------------------
+// 1. Aligned text
+// ----------------------
+
+// WriteText writes the table as aligned plain text, with a rule under the header.
+func (t *Table) WriteText(w io.Writer) error {
+	header, cells := t.headers(truncate), t.cells(truncate)
+	widths := columnWidths(header, cells)
+	bw := bufio.NewWriter(w)
+
+	line := func(row []string) {
+		parts := make([]string, len(row))
+		for i, c := range row {
+			parts[i] = pad(c, widths[i], t.Columns[i].Align)
+		}
+		bw.WriteString(strings.TrimRight(strings.Join(parts, " | "), " "))
+		bw.WriteByte('\n')
+	}
+
+	line(header)
+	rule := make([]string, len(widths))
+	for i, n := range widths {
+		rule[i] = strings.Repeat("-", n)
+	}
+	bw.WriteString(strings.Join(rule, "-+-"))
+	bw.WriteByte('\n')
+	for _, row := range cells {
+		line(row)
+	}
+	return bw.Flush()
+}
+
+// String returns the aligned text form of the table.
+func (t *Table) String() string {
+	var b strings.Builder
+	t.WriteText(&b)
+	return b.String()
+}
+
+// ----------------------
+// 2. Markdown
+// ----------------------
+
+// WriteMarkdown writes the table as a GitHub-flavoured Markdown table.
+// Column alignment is expressed with colons in the separator row.
+func (t *Table) WriteMarkdown(w io.Writer) error {
+	// Pipes are escaped before truncating, so MaxWidth and the column
+	// widths both count the backslashes.
+	header, cells := t.headers(truncateEscaped), t.cells(truncateEscaped)
+	widths := columnWidths(header, cells)
+	bw := bufio.NewWriter(w)
+
+	line := func(row []string) {
+		bw.WriteString("|")
+		for i, c := range row {
+			bw.WriteString(" " + pad(c, widths[i], t.Columns[i].Align) + " |")
+		}
+		bw.WriteByte('\n')
+	}
+
+	line(header)
+	bw.WriteString("|")
+	for i, col := range t.Columns {
+		n := max(widths[i], 3)
+		switch col.Align {
+		case AlignRight:
+			bw.WriteString(" " + strings.Repeat("-", n-1) + ": |")
+		case AlignCenter:
+			bw.WriteString(" :" + strings.Repeat("-", n-2) + ": |")
+		default:
+			bw.WriteString(" " + strings.Repeat("-", n) + " |")
+		}
+	}
+	bw.WriteByte('\n')
+	for _, row := range cells {
+		line(row)
+	}
+	return bw.Flush()
+}
+
+// ----------------------
+// 3. CSV and JSON Lines
+// ----------------------
+
+// WriteCSV writes a header record and one record per row.
+// Cells are not truncated: CSV is for machines, not terminals.
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		header[i] = c.Header
+	}
+	cw.Write(header)
+	for _, row := range t.Rows {
+		rec := make([]string, len(t.Columns)) // short rows get empty fields
+		for i := range rec {
+			if i < len(row) {
+				rec[i] = cell(row[i])
+			}
+		}
+		cw.Write(rec)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSONLines writes one JSON object per row, keyed by header in column order.
+// Values keep their JSON types (numbers stay numbers).
+func (t *Table) WriteJSONLines(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, row := range t.Rows {
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, col := range t.Columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			var v any
+			if i < len(row) {
+				v = row[i]
+			}
+			k, _ := json.Marshal(col.Header)
+			val, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			buf.Write(k)
+			buf.WriteByte(':')
+			buf.Write(val)
+		}
+		buf.WriteString("}\n")
+		bw.Write(buf.Bytes())
+	}
+	return bw.Flush()
+}
+
+// ----------------------
+// 4. Helpers
+// ----------------------
+
+// headers returns the column headers, each shortened to its MaxWidth by fit.
+func (t *Table) headers(fit func(s string, limit int) string) []string {
+	h := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		h[i] = fit(c.Header, c.MaxWidth)
+	}
+	return h
+}
+
+// cells formats every value for the text formats and shortens it to its
+// column's MaxWidth by fit. Line breaks are flattened so each row stays on
+// one line.
+func (t *Table) cells(fit func(s string, limit int) string) [][]string {
+	flat := strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ")
+	out := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		out[r] = make([]string, len(t.Columns))
+		for i := range t.Columns {
+			var v any
+			if i < len(row) {
+				v = row[i]
+			}
+			out[r][i] = fit(flat.Replace(cell(v)), t.Columns[i].MaxWidth)
+		}
+	}
+	return out
+}
+
+// columnWidths returns the width of each column: its widest cell or header.
+func columnWidths(header []string, cells [][]string) []int {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = width(h)
+	}
+	for _, row := range cells {
+		for i, c := range row {
+			widths[i] = max(widths[i], width(c))
+		}
+	}
+	return widths
+}
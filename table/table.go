@@ -0,0 +1,155 @@
+// Package table renders slices of structs as aligned text tables,
+// CSV, Markdown or JSON Lines.
+//
+// Where PrintSlice prints one "Index 0: {Alice 30}" line per element,
+// FromStructs turns each exported field into a column:
+//
+//	Name  | Age
+//	------+----
+//	Alice |  30
+//
+// Columns are configured with a `table` struct tag:
+//
+//	Name string `table:"Full name,width=12"` // header and maximum width
+//	Age  int    `table:",align=right"`       // keep the field name as header
+//	note string                             // unexported fields are skipped
+//	ID   int    `table:"-"`                  // so are fields tagged "-"
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Align is the horizontal alignment of a column.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Column describes one column of a Table.
+type Column struct {
+	Header   string
+	Align    Align
+	MaxWidth int // in terminal cells; longer cells are truncated with "…". 0 = unlimited
+}
+
+// Table is a list of columns and rows of raw values.
+// Rows keep the original values so JSON Lines output keeps their types.
+type Table struct {
+	Columns []Column
+	Rows    [][]any
+}
+
+// FromStructs builds a Table from a slice of structs or struct pointers.
+// Numeric fields are right-aligned unless their tag says otherwise.
+// Nil pointers produce empty rows.
+func FromStructs[T any](rows []T) (*Table, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("table: %s is not a struct type", typ)
+	}
+
+	var cols []Column
+	var fields [][]int // field index paths, parallel to cols
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		col, ok, err := parseTag(f)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cols = append(cols, col)
+			fields = append(fields, f.Index)
+		}
+	}
+
+	t := &Table{Columns: cols, Rows: make([][]any, len(rows))}
+	for i := range rows {
+		v := reflect.ValueOf(&rows[i]).Elem()
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				t.Rows[i] = make([]any, len(cols))
+				continue
+			}
+			v = v.Elem()
+		}
+		row := make([]any, len(cols))
+		for j, idx := range fields {
+			if fv, err := v.FieldByIndexErr(idx); err == nil {
+				row[j] = fv.Interface()
+			}
+		}
+		t.Rows[i] = row
+	}
+	return t, nil
+}
+
+// parseTag reads the `table` tag of f. ok is false for fields tagged "-".
+func parseTag(f reflect.StructField) (col Column, ok bool, err error) {
+	tag := f.Tag.Get("table")
+	if tag == "-" {
+		return Column{}, false, nil
+	}
+	parts := strings.Split(tag, ",")
+	col.Header = parts[0]
+	if col.Header == "" {
+		col.Header = f.Name
+	}
+	if isNumeric(f.Type) {
+		col.Align = AlignRight
+	}
+	for _, opt := range parts[1:] {
+		key, val, _ := strings.Cut(opt, "=")
+		switch key {
+		case "align":
+			switch val {
+			case "left":
+				col.Align = AlignLeft
+			case "right":
+				col.Align = AlignRight
+			case "center":
+				col.Align = AlignCenter
+			default:
+				return col, false, fmt.Errorf("table: field %s: unknown align %q", f.Name, val)
+			}
+		case "width":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return col, false, fmt.Errorf("table: field %s: bad width %q", f.Name, val)
+			}
+			col.MaxWidth = n
+		default:
+			return col, false, fmt.Errorf("table: field %s: unknown option %q", f.Name, key)
+		}
+	}
+	return col, true, nil
+}
+
+func isNumeric(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// cell formats a raw value for the text formats. nil renders as "".
+func cell(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
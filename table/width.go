@@ -0,0 +1,100 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+)
+
+// wideRanges lists the East Asian Wide and Fullwidth blocks, plus emoji,
+// which take two terminal cells.
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK radicals, punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Emoji: pictographs, emoticons
+	{0x1F900, 0x1F9FF}, // Emoji: supplemental symbols
+	{0x20000, 0x3FFFD}, // CJK extensions B and later
+}
+
+// runeWidth returns how many terminal cells r occupies: 0, 1 or 2.
+func runeWidth(r rune) int {
+	switch {
+	case r == 0, unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0 // combining marks and invisible format characters
+	case r < 0x1100:
+		return 1
+	}
+	for _, w := range wideRanges {
+		if r >= w.lo && r <= w.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+// width returns the display width of s in terminal cells.
+func width(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// truncate shortens s to at most limit cells, ending with "…" when cut.
+// A limit of 0 means no limit.
+func truncate(s string, limit int) string { return fit(s, limit, false) }
+
+// truncateEscaped escapes every "|" in s as `\|` for Markdown, then
+// truncates the result like truncate without splitting an escape.
+func truncateEscaped(s string, limit int) string { return fit(s, limit, true) }
+
+func fit(s string, limit int, escape bool) string {
+	if escape {
+		if full := strings.ReplaceAll(s, "|", `\|`); limit <= 0 || width(full) <= limit {
+			return full
+		}
+	} else if limit <= 0 || width(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		piece, w := string(r), runeWidth(r)
+		if escape && r == '|' {
+			piece, w = `\|`, 2
+		}
+		if used+w > limit-1 { // keep one cell for the ellipsis
+			break
+		}
+		b.WriteString(piece)
+		used += w
+	}
+	b.WriteString("…")
+	return b.String()
+}
+
+// pad aligns s within a field of the given width.
+func pad(s string, w int, align Align) string {
+	gap := w - width(s)
+	if gap <= 0 {
+		return s
+	}
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + s
+	case AlignCenter:
+		left := gap / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+	default:
+		return s + strings.Repeat(" ", gap)
+	}
+}
@@ -0,0 +1,235 @@
+// Package trie provides a string-keyed prefix tree for prefix search,
+// autocomplete and longest-prefix routing.
+//
+// A Trie made by New stores one byte per edge. One made by NewRadix
+// compresses chains of single-child nodes into one edge (a radix tree),
+// which uses far fewer nodes for long keys with shared prefixes.
+// Both behave identically; only memory use and node count differ.
+// Keys are compared byte by byte, the same order as Go's string comparison.
+package trie
+
+import (
+	"iter"
+	"sort"
+	"strings"
+)
+
+type node[V any] struct {
+	label    string     // edge label leading into this node
+	children []*node[V] // sorted by label[0]
+	value    V
+	has      bool // whether a key ends here
+}
+
+// Trie maps string keys to values of type V.
+type Trie[V any] struct {
+	root    node[V]
+	compact bool
+	size    int
+}
+
+// New returns an empty trie with one byte per edge.
+func New[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// NewRadix returns an empty trie in compact radix-tree mode.
+func NewRadix[V any]() *Trie[V] {
+	return &Trie[V]{compact: true}
+}
+
+// Len returns the number of keys.
+func (t *Trie[V]) Len() int { return t.size }
+
+// ----------------------
+// 1. Insert, Get, Delete
+// ----------------------
+
+// Insert stores value under key, replacing any previous value.
+func (t *Trie[V]) Insert(key string, value V) {
+	n, rest := &t.root, key
+	for rest != "" {
+		i, c := n.child(rest[0])
+		if c == nil {
+			label := rest
+			if !t.compact {
+				label = rest[:1]
+			}
+			c = &node[V]{label: label}
+			n.insertChild(i, c)
+		} else if p := commonPrefix(c.label, rest); p < len(c.label) {
+			// Split c so that the shared part becomes its own node.
+			mid := &node[V]{label: c.label[:p], children: []*node[V]{c}}
+			c.label = c.label[p:]
+			n.children[i] = mid
+			c = mid
+		}
+		n, rest = c, rest[len(c.label):]
+	}
+	if !n.has {
+		t.size++
+	}
+	n.value, n.has = value, true
+}
+
+// Get returns the value stored under key.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.has {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Delete removes key and reports whether it was present.
+// Nodes left without keys are pruned; in radix mode a node left with a single
+// child is merged into it again.
+func (t *Trie[V]) Delete(key string) bool {
+	path := []*node[V]{&t.root}
+	n, rest := &t.root, key
+	for rest != "" {
+		_, c := n.child(rest[0])
+		if c == nil || !strings.HasPrefix(rest, c.label) {
+			return false
+		}
+		n, rest = c, rest[len(c.label):]
+		path = append(path, n)
+	}
+	if !n.has {
+		return false
+	}
+	var zero V
+	n.value, n.has = zero, false
+	t.size--
+
+	for i := len(path) - 1; i > 0; i-- {
+		n, parent := path[i], path[i-1]
+		switch {
+		case n.has:
+			return true
+		case len(n.children) == 0:
+			parent.removeChild(n)
+		case len(n.children) == 1 && t.compact:
+			c := n.children[0]
+			c.label = n.label + c.label
+			j, _ := parent.child(c.label[0])
+			parent.children[j] = c
+			return true
+		default:
+			return true
+		}
+	}
+	return true
+}
+
+// ----------------------
+// 2. Prefix queries
+// ----------------------
+
+// All iterates over every key in lexicographic order.
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return t.WithPrefix("")
+}
+
+// WithPrefix iterates over the keys starting with prefix, in lexicographic order.
+func (t *Trie[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n, rest, consumed := &t.root, prefix, ""
+		for rest != "" {
+			_, c := n.child(rest[0])
+			if c == nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(rest, c.label):
+				rest = rest[len(c.label):]
+			case strings.HasPrefix(c.label, rest):
+				rest = "" // prefix ends inside this edge
+			default:
+				return
+			}
+			consumed += c.label
+			n = c
+		}
+		walk(n, consumed, yield)
+	}
+}
+
+// LongestPrefix returns the longest stored key that is a prefix of s,
+// as used for routing "/lessons/maps/1" to a "/lessons/maps" handler.
+func (t *Trie[V]) LongestPrefix(s string) (string, V, bool) {
+	var bestKey string
+	best, found := t.root.value, t.root.has // the empty key is a prefix of everything
+	n, consumed := &t.root, 0
+	for consumed < len(s) {
+		_, c := n.child(s[consumed])
+		if c == nil || !strings.HasPrefix(s[consumed:], c.label) {
+			break
+		}
+		n, consumed = c, consumed+len(c.label)
+		if n.has {
+			bestKey, best, found = s[:consumed], n.value, true
+		}
+	}
+	return bestKey, best, found
+}
+
+// ----------------------
+// 3. Internals
+// ----------------------
+
+// walk yields n's key (if any) and then its subtree, depth first.
+func walk[V any](n *node[V], key string, yield func(string, V) bool) bool {
+	if n.has && !yield(key, n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !walk(c, key+c.label, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// find returns the node whose path spells exactly key.
+func (t *Trie[V]) find(key string) *node[V] {
+	n, rest := &t.root, key
+	for rest != "" {
+		_, c := n.child(rest[0])
+		if c == nil || !strings.HasPrefix(rest, c.label) {
+			return nil
+		}
+		n, rest = c, rest[len(c.label):]
+	}
+	return n
+}
+
+// child returns the child whose label starts with b, or the index where
+// such a child would be inserted and nil.
+func (n *node[V]) child(b byte) (int, *node[V]) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+	if i < len(n.children) && n.children[i].label[0] == b {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+func (n *node[V]) insertChild(i int, c *node[V]) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+}
+
+func (n *node[V]) removeChild(c *node[V]) {
+	i, _ := n.child(c.label[0])
+	n.children = append(n.children[:i], n.children[i+1:]...)
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,194 @@
+// Package tuple provides small fixed-size tuples whose fields may differ in type,
+// so a function can hand back (name, age) as one value.
+//
+// Tuples print as "(a, b)" and marshal to JSON as arrays: [a, b].
+package tuple
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+)
+
+// ----------------------
+// 1. Pair
+// ----------------------
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// NewPair builds a Pair; the type parameters are inferred.
+func NewPair[A, B any](a A, b B) Pair[A, B] {
+	return Pair[A, B]{a, b}
+}
+
+// Unpack returns the fields as multiple values.
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// Swap returns the pair with its fields reversed.
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{p.Second, p.First}
+}
+
+// String formats the pair as (first, second).
+func (p Pair[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// MarshalJSON encodes the pair as a two-element array.
+func (p Pair[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{p.First, p.Second})
+}
+
+// UnmarshalJSON decodes a two-element array.
+func (p *Pair[A, B]) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, &p.First, &p.Second)
+}
+
+// ComparePair orders pairs lexicographically: by First, then by Second.
+// It returns -1, 0 or +1 like cmp.Compare.
+func ComparePair[A, B cmp.Ordered](x, y Pair[A, B]) int {
+	if c := cmp.Compare(x.First, y.First); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.Second, y.Second)
+}
+
+// ----------------------
+// 2. Triple
+// ----------------------
+
+// Triple holds three values of possibly different types.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// NewTriple builds a Triple; the type parameters are inferred.
+func NewTriple[A, B, C any](a A, b B, c C) Triple[A, B, C] {
+	return Triple[A, B, C]{a, b, c}
+}
+
+// Unpack returns the fields as multiple values.
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
+
+// Reverse returns the triple with its fields in reverse order.
+func (t Triple[A, B, C]) Reverse() Triple[C, B, A] {
+	return Triple[C, B, A]{t.Third, t.Second, t.First}
+}
+
+// String formats the triple as (first, second, third).
+func (t Triple[A, B, C]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", t.First, t.Second, t.Third)
+}
+
+// MarshalJSON encodes the triple as a three-element array.
+func (t Triple[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.First, t.Second, t.Third})
+}
+
+// UnmarshalJSON decodes a three-element array.
+func (t *Triple[A, B, C]) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, &t.First, &t.Second, &t.Third)
+}
+
+// CompareTriple orders triples lexicographically.
+func CompareTriple[A, B, C cmp.Ordered](x, y Triple[A, B, C]) int {
+	if c := cmp.Compare(x.First, y.First); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(x.Second, y.Second); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.Third, y.Third)
+}
+
+// ----------------------
+// 3. Quad
+// ----------------------
+
+// Quad holds four values of possibly different types.
+type Quad[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// NewQuad builds a Quad; the type parameters are inferred.
+func NewQuad[A, B, C, D any](a A, b B, c C, d D) Quad[A, B, C, D] {
+	return Quad[A, B, C, D]{a, b, c, d}
+}
+
+// Unpack returns the fields as multiple values.
+func (q Quad[A, B, C, D]) Unpack() (A, B, C, D) {
+	return q.First, q.Second, q.Third, q.Fourth
+}
+
+// Reverse returns the quad with its fields in reverse order.
+func (q Quad[A, B, C, D]) Reverse() Quad[D, C, B, A] {
+	return Quad[D, C, B, A]{q.Fourth, q.Third, q.Second, q.First}
+}
+
+// String formats the quad as (first, second, third, fourth).
+func (q Quad[A, B, C, D]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v)", q.First, q.Second, q.Third, q.Fourth)
+}
+
+// MarshalJSON encodes the quad as a four-element array.
+func (q Quad[A, B, C, D]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{q.First, q.Second, q.Third, q.Fourth})
+}
+
+// UnmarshalJSON decodes a four-element array.
+func (q *Quad[A, B, C, D]) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, &q.First, &q.Second, &q.Third, &q.Fourth)
+}
+
+// CompareQuad orders quads lexicographically.
+func CompareQuad[A, B, C, D cmp.Ordered](x, y Quad[A, B, C, D]) int {
+	if c := cmp.Compare(x.First, y.First); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(x.Second, y.Second); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(x.Third, y.Third); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.Fourth, y.Fourth)
+}
+
+// ----------------------
+// 4. JSON helper
+// ----------------------
+
+// unmarshalArray decodes a JSON array element by element into fields,
+// requiring exactly len(fields) elements. As with encoding/json, null is a
+// no-op and leaves the fields unchanged.
+func unmarshalArray(data []byte, fields ...any) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return nil // null
+	}
+	if len(raw) != len(fields) {
+		return fmt.Errorf("tuple: expected %d elements, got %d", len(fields), len(raw))
+	}
+	for i, f := range fields {
+		if err := json.Unmarshal(raw[i], f); err != nil {
+			return fmt.Errorf("tuple: element %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package tuple
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPairJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Pair[string, int]
+		wantErr bool
+	}{
+		{"array", `["ann", 42]`, Pair[string, int]{"ann", 42}, false},
+		{"null keeps the pair", `null`, Pair[string, int]{"old", 1}, false},
+		{"too short", `["ann"]`, Pair[string, int]{}, true},
+		{"too long", `["ann", 42, true]`, Pair[string, int]{}, true},
+		{"empty", `[]`, Pair[string, int]{}, true},
+		{"wrong element type", `["ann", "42"]`, Pair[string, int]{}, true},
+		{"object", `{"First": "ann"}`, Pair[string, int]{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Pair[string, int]{"old", 1}
+			err := json.Unmarshal([]byte(tt.data), &p)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decoded %s as %v", tt.data, p)
+				}
+				return
+			}
+			if err != nil || p != tt.want {
+				t.Fatalf("got %v, %v; want %v", p, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tr := Triple[string, int, bool]{"a", 1, true}
+	q := Quad[int, int, string, float64]{1, 2, "x", 0.5}
+	data, err := json.Marshal(struct {
+		T Triple[string, int, bool]
+		Q Quad[int, int, string, float64]
+	}{tr, q})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"T":["a",1,true],"Q":[1,2,"x",0.5]}`; string(data) != want {
+		t.Fatalf("encoded %s, want %s", data, want)
+	}
+	var got struct {
+		T Triple[string, int, bool]
+		Q Quad[int, int, string, float64]
+	}
+	if err := json.Unmarshal(data, &got); err != nil || got.T != tr || got.Q != q {
+		t.Fatalf("decoded %v, %v, %v", got.T, got.Q, err)
+	}
+
+	// null leaves Triple and Quad unchanged too.
+	if err := json.Unmarshal([]byte(`{"T":null,"Q":null}`), &got); err != nil || got.T != tr || got.Q != q {
+		t.Fatalf("null changed the tuples to %v, %v (%v)", got.T, got.Q, err)
+	}
+}